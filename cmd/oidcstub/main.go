@@ -0,0 +1,44 @@
+// oidcstub — локальный провайдер OpenID Connect для разработки входа админов (см. internal/oidc/oidcstub).
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"photannie/internal/oidc/oidcstub"
+)
+
+func main() {
+	log := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	addr := getEnv("OIDC_STUB_ADDR", ":9096")
+	cfg := oidcstub.Config{
+		Issuer:       getEnv("OIDC_STUB_ISSUER", "http://localhost:9096"),
+		PublicURL:    os.Getenv("OIDC_STUB_PUBLIC_URL"),
+		ClientID:     getEnv("OIDC_STUB_CLIENT_ID", "photannie"),
+		ClientSecret: getEnv("OIDC_STUB_CLIENT_SECRET", ""),
+		Email:        getEnv("OIDC_STUB_EMAIL", "admin@example.com"),
+		Logger:       log,
+	}
+
+	s, err := oidcstub.New(cfg)
+	if err != nil {
+		log.Error("stub init failed", "err", err)
+		os.Exit(1)
+	}
+
+	log.Info("oidc stub listening", "addr", addr, "issuer", cfg.Issuer, "client_id", cfg.ClientID)
+	if err := http.ListenAndServe(addr, s.Handler()); err != nil {
+		log.Error("server stopped", "err", err)
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, def string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return def
+}
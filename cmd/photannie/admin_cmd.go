@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"photannie/internal/config"
+	"photannie/internal/domain"
+	"photannie/internal/repository/postgres"
+	"photannie/internal/service/admin"
+)
+
+const adminUsage = `usage:
+  photannie admin create -username NAME [-role ROLE]  создать администратора (роль по умолчанию owner)
+  photannie admin set-password -username NAME         сменить пароль, завершить сессии и отозвать API-ключи
+  photannie admin set-role -username NAME -role ROLE  сменить роль администратора
+  photannie admin reset-totp -username NAME           отключить TOTP (потеряны устройство и коды восстановления)
+
+Роли: owner — всё; manager — расписание без управления администраторами;
+viewer — только просмотр расписания, телефоны клиентов скрыты.
+
+Для create и set-password пароль читается из первой строки stdin, например:
+  printf '%s\n' "$PASSWORD" | photannie admin create -username anna
+`
+
+// runAdminCommand — управление учётками администраторов из CLI (миграции к этому моменту уже применены).
+func runAdminCommand(ctx context.Context, cfg config.Config, log *slog.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New(adminUsage)
+	}
+
+	action := args[0]
+	fs := flag.NewFlagSet("admin "+action, flag.ContinueOnError)
+	username := fs.String("username", "", "логин администратора")
+	role := fs.String("role", "", "роль: owner, manager, viewer")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *username == "" {
+		return errors.New(adminUsage)
+	}
+	if action == "create" && *role == "" {
+		*role = string(domain.AdminRoleOwner)
+	}
+	if action == "set-role" && *role == "" {
+		return errors.New(adminUsage)
+	}
+
+	var password string
+	if action == "create" || action == "set-password" {
+		p, err := readPassword(os.Stdin)
+		if err != nil {
+			return err
+		}
+		password = p
+	}
+
+	pool, err := postgres.NewPool(ctx, postgres.PoolConfig{
+		ConnString: cfg.Postgres.ConnString,
+		MaxConns:   2,
+		MinConns:   1,
+	})
+	if err != nil {
+		return fmt.Errorf("postgres pool: %w", err)
+	}
+	defer pool.Close()
+
+	admins, err := admin.New(admin.Deps{
+		Admins: postgres.NewAdminRepository(pool),
+		Logger: log,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "create":
+		a, err := admins.CreateAdmin(ctx, *username, password, domain.AdminRole(*role))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("admin %s created (id=%s, role=%s)\n", a.Username, a.ID, a.Role)
+	case "set-password":
+		a, err := admins.SetPassword(ctx, *username, password)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("password for %s updated, sessions ended, api keys revoked\n", a.Username)
+	case "set-role":
+		a, err := admins.SetRole(ctx, *username, domain.AdminRole(*role))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("role for %s set to %s\n", a.Username, a.Role)
+	case "reset-totp":
+		if err := admins.ResetTOTP(ctx, *username); err != nil {
+			return err
+		}
+		fmt.Printf("totp for %s disabled\n", admin.NormalizeUsername(*username))
+	default:
+		return errors.New(adminUsage)
+	}
+
+	return nil
+}
+
+func readPassword(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("password is empty: pass it on stdin")
+	}
+	return password, nil
+}
@@ -36,6 +36,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "admin" {
+		if err := runAdminCommand(ctx, cfg, log, os.Args[2:]); err != nil {
+			_, _ = os.Stderr.WriteString(err.Error() + "\n")
+			os.Exit(1)
+		}
+		return
+	}
+
 	a, err := app.New(ctx, cfg, log)
 	if err != nil {
 		log.Error("app init failed", "err", err)
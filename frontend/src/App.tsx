@@ -2,12 +2,14 @@ import { Navigate, Route, Routes } from "react-router-dom";
 import ClientPage from "./pages/ClientPage";
 import AdminLoginPage from "./pages/AdminLoginPage";
 import AdminPage from "./pages/AdminPage";
+import ManageBookingPage from "./pages/ManageBookingPage";
 import RequireAdmin from "./components/admin/RequireAdmin";
 
 export default function App() {
     return (
             <Routes>
                 <Route path="/" element={<ClientPage />} />
+                <Route path="/booking/:id" element={<ManageBookingPage />} />
 
                 <Route path="/admin/login" element={<AdminLoginPage />} />
                 <Route
@@ -1,42 +1,165 @@
-import { requestJson } from "./http";
-import type {
-    AdminBookingsByDateResponse,
-    AdminSessionLoginRequest,
-    BookingDetail,
-    CancelBookingRequest,
-} from "./types";
-
-export function adminSessionLogin(body: AdminSessionLoginRequest) {
-    return requestJson<void>({
-        method: "POST",
-        path: "/api/admin/session/login",
-        body,
-        withCredentials: true,
-    });
-}
-
-export function adminListBookingsByDate(date: string) {
-    return requestJson<AdminBookingsByDateResponse>({
-        method: "GET",
-        path: "/api/admin/bookings",
-        query: { date },
-        withCredentials: true,
-    });
-}
-
-export function adminGetBooking(bookingId: string) {
-    return requestJson<BookingDetail>({
-        method: "GET",
-        path: `/api/admin/bookings/${bookingId}`,
-        withCredentials: true,
-    });
-}
-
-export function adminCancelBooking(bookingId: string, body?: CancelBookingRequest) {
-    return requestJson<BookingDetail>({
-        method: "POST",
-        path: `/api/admin/bookings/${bookingId}/cancel`,
-        body,
-        withCredentials: true,
-    });
-}
+import { API_BASE_URL, joinUrl, requestJson } from "./http";
+import type {
+    AdminBookingRequestsResponse,
+    AdminBookingsByDateResponse,
+    AdminClientsResponse,
+    AdminLoginMFAResponse,
+    AdminMeResponse,
+    AdminSessionLoginRequest,
+    BookingDetail,
+    CancelBookingRequest,
+    Client,
+    ClientMergeRequest,
+    MfaCodeRequest,
+    RescheduleBookingRequest,
+} from "./types";
+
+// undefined — вход завершён; { mfa_required: true } — нужен второй шаг (adminSessionMfa).
+export function adminSessionLogin(body: AdminSessionLoginRequest) {
+    return requestJson<AdminLoginMFAResponse | undefined>({
+        method: "POST",
+        path: "/api/admin/session/login",
+        body,
+        withCredentials: true,
+    });
+}
+
+export function adminSessionMfa(body: MfaCodeRequest) {
+    return requestJson<void>({
+        method: "POST",
+        path: "/api/admin/session/mfa",
+        body,
+        withCredentials: true,
+    });
+}
+
+// Вход через OpenID Connect — обычная навигация браузера, не fetch: сервер уводит на страницу провайдера.
+export function adminOidcLoginUrl() {
+    return joinUrl(API_BASE_URL, "/api/admin/oidc/login");
+}
+
+export function adminGetMe() {
+    return requestJson<AdminMeResponse>({
+        method: "GET",
+        path: "/api/admin/me",
+        withCredentials: true,
+    });
+}
+
+export function adminSessionLogout() {
+    return requestJson<void>({
+        method: "POST",
+        path: "/api/admin/session/logout",
+        withCredentials: true,
+    });
+}
+
+export function adminListBookingsByDate(date: string) {
+    return requestJson<AdminBookingsByDateResponse>({
+        method: "GET",
+        path: "/api/admin/bookings",
+        query: { date },
+        withCredentials: true,
+    });
+}
+
+export function adminGetBooking(bookingId: string) {
+    return requestJson<BookingDetail>({
+        method: "GET",
+        path: `/api/admin/bookings/${bookingId}`,
+        withCredentials: true,
+    });
+}
+
+export function adminCancelBooking(bookingId: string, body?: CancelBookingRequest) {
+    return requestJson<BookingDetail>({
+        method: "POST",
+        path: `/api/admin/bookings/${bookingId}/cancel`,
+        body,
+        withCredentials: true,
+    });
+}
+
+export function adminConfirmBooking(bookingId: string) {
+    return requestJson<BookingDetail>({
+        method: "POST",
+        path: `/api/admin/bookings/${bookingId}/confirm`,
+        withCredentials: true,
+    });
+}
+
+export function adminCompleteBooking(bookingId: string) {
+    return requestJson<BookingDetail>({
+        method: "POST",
+        path: `/api/admin/bookings/${bookingId}/complete`,
+        withCredentials: true,
+    });
+}
+
+export function adminMarkNoShow(bookingId: string) {
+    return requestJson<BookingDetail>({
+        method: "POST",
+        path: `/api/admin/bookings/${bookingId}/no-show`,
+        withCredentials: true,
+    });
+}
+
+export function adminListBookingRequests() {
+    return requestJson<AdminBookingRequestsResponse>({
+        method: "GET",
+        path: "/api/admin/booking-requests",
+        withCredentials: true,
+    });
+}
+
+export function adminApproveBooking(bookingId: string) {
+    return requestJson<BookingDetail>({
+        method: "POST",
+        path: `/api/admin/booking-requests/${bookingId}/approve`,
+        withCredentials: true,
+    });
+}
+
+export function adminRejectBooking(bookingId: string, body?: CancelBookingRequest) {
+    return requestJson<BookingDetail>({
+        method: "POST",
+        path: `/api/admin/booking-requests/${bookingId}/reject`,
+        body,
+        withCredentials: true,
+    });
+}
+
+export function adminRescheduleBooking(bookingId: string, body: RescheduleBookingRequest) {
+    return requestJson<BookingDetail>({
+        method: "POST",
+        path: `/api/admin/bookings/${bookingId}/reschedule`,
+        body,
+        withCredentials: true,
+    });
+}
+
+export function adminListClients(q?: string, limit?: number, offset?: number) {
+    return requestJson<AdminClientsResponse>({
+        method: "GET",
+        path: "/api/admin/clients",
+        query: { q: q || undefined, limit, offset },
+        withCredentials: true,
+    });
+}
+
+export function adminGetClient(clientId: string) {
+    return requestJson<Client>({
+        method: "GET",
+        path: `/api/admin/clients/${clientId}`,
+        withCredentials: true,
+    });
+}
+
+export function adminMergeClients(clientId: string, body: ClientMergeRequest) {
+    return requestJson<Client>({
+        method: "POST",
+        path: `/api/admin/clients/${clientId}/merge`,
+        body,
+        withCredentials: true,
+    });
+}
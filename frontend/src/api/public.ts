@@ -2,8 +2,13 @@ import { requestJson } from "./http";
 import type {
     BookingCreateRequest,
     BookingCreateResponse,
+    ClientBooking,
+    ClientCancelBookingRequest,
+    ClientRescheduleBookingRequest,
     FreeSlotsResponse,
     PublicConfig,
+    SlotHoldRequest,
+    SlotHoldResponse,
 } from "./types";
 
 export function getPublicConfig() {
@@ -28,3 +33,40 @@ export function createBooking(body: BookingCreateRequest) {
         body,
     });
 }
+
+export function createSlotHold(body: SlotHoldRequest) {
+    return requestJson<SlotHoldResponse>({
+        method: "POST",
+        path: "/api/public/holds",
+        body,
+    });
+}
+
+export function getClientBooking(id: string, token: string) {
+    return requestJson<ClientBooking>({
+        method: "GET",
+        path: `/api/public/bookings/${id}`,
+        query: { token },
+    });
+}
+
+export function cancelClientBooking(id: string, body: ClientCancelBookingRequest) {
+    return requestJson<ClientBooking>({
+        method: "POST",
+        path: `/api/public/bookings/${id}/cancel`,
+        body,
+    });
+}
+
+export function rescheduleClientBooking(id: string, body: ClientRescheduleBookingRequest) {
+    return requestJson<ClientBooking>({
+        method: "POST",
+        path: `/api/public/bookings/${id}/reschedule`,
+        body,
+    });
+}
+
+// Ссылка, по которой клиент смотрит и отменяет свою запись (ManageBookingPage).
+export function manageBookingUrl(id: string, token: string) {
+    return `${window.location.origin}/booking/${encodeURIComponent(id)}?token=${encodeURIComponent(token)}`;
+}
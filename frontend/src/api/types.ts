@@ -1,92 +1,208 @@
-export type ISODate = string; // YYYY-MM-DD
-export type TimeHHMM = string; // HH:mm
-
-export type ErrorResponse = {
-    code: string;
-    message: string;
-    details?: Record<string, unknown> | null;
-};
-
-export type FieldError = {
-    field: string;
-    message: string;
-};
-
-export type ValidationErrorResponse = {
-    code: "validation_error";
-    message: string;
-    fields: FieldError[];
-};
-
-export type HealthCheckResponse = {
-    status: "ok";
-    time: string;
-};
-
-export type PublicConfig = {
-    studio_timezone: string;
-    booking_window_days: number;
-    work_days: number[];
-    work_start: TimeHHMM;
-    work_end: TimeHHMM;
-    slot_minutes: 30;
-    max_session_minutes: number;
-    server_time?: string;
-};
-
-export type FreeSlotsResponse = {
-    date: ISODate;
-    free_slots: TimeHHMM[];
-};
-
-export type BookingStatus = "active" | "cancelled";
-
-export type BookingCreateRequest = {
-    date: ISODate;
-    start_time: TimeHHMM;
-    duration_minutes: number;
-    name: string;
-    phone: string;
-    comment?: string | null;
-};
-
-export type BookingCreateResponse = {
-    id: string;
-    date: ISODate;
-    start_time: TimeHHMM;
-    end_time: TimeHHMM;
-    duration_minutes: number;
-    status: BookingStatus;
-    created_at: string;
-};
-
-export type BookingSummary = {
-    id: string;
-    date: ISODate;
-    start_time: TimeHHMM;
-    end_time: TimeHHMM;
-    duration_minutes: number;
-    client_name: string;
-    client_phone: string;
-    comment?: string | null;
-    status: BookingStatus;
-    created_at: string;
-    cancelled_at?: string | null;
-};
-
-export type BookingDetail = BookingSummary & {
-    cancel_reason?: string | null;
-};
-
-export type AdminBookingsByDateResponse = {
-    date: ISODate;
-    items: BookingSummary[];
-};
-
-export type AdminSessionLoginRequest = {
-    password: string;
-};
-
-export type CancelBookingRequest = {
-    reason?: string | null;
-};
+export type ISODate = string; // YYYY-MM-DD
+export type TimeHHMM = string; // HH:mm
+
+export type ErrorResponse = {
+    code: string;
+    message: string;
+    details?: Record<string, unknown> | null;
+};
+
+export type FieldError = {
+    field: string;
+    message: string;
+};
+
+export type ValidationErrorResponse = {
+    code: "validation_error";
+    message: string;
+    fields: FieldError[];
+};
+
+export type HealthCheckResponse = {
+    status: "ok";
+    time: string;
+};
+
+export type PublicConfig = {
+    studio_timezone: string;
+    booking_window_days: number;
+    work_days: number[];
+    work_start: TimeHHMM;
+    work_end: TimeHHMM;
+    slot_minutes: number;
+    max_session_minutes: number;
+    server_time?: string;
+};
+
+export type FreeSlotsResponse = {
+    date: ISODate;
+    duration_minutes: number;
+    free_slots: TimeHHMM[];
+};
+
+export type BookingStatus = "pending" | "confirmed" | "completed" | "no_show" | "cancelled";
+
+export type BookingCreateRequest = {
+    date: ISODate;
+    start_time: TimeHHMM;
+    duration_minutes: number;
+    name: string;
+    phone: string;
+    comment?: string | null;
+    // Токен удержания этого же интервала (createSlotHold).
+    hold_token?: string;
+};
+
+export type SlotHoldRequest = {
+    date: ISODate;
+    start_time: TimeHHMM;
+    duration_minutes?: number;
+    service_id?: string;
+    replace_hold_token?: string;
+};
+
+export type SlotHoldResponse = {
+    hold_token: string;
+    date: ISODate;
+    start_time: TimeHHMM;
+    end_time: TimeHHMM;
+    duration_minutes: number;
+    expires_at: string;
+};
+
+export type BookingCreateResponse = {
+    id: string;
+    date: ISODate;
+    start_time: TimeHHMM;
+    end_time: TimeHHMM;
+    duration_minutes: number;
+    status: BookingStatus;
+    created_at: string;
+    // Секрет ссылки управления записью; второй раз его не получить.
+    manage_token: string;
+    // Только у заявки (status=pending): до этого момента студия должна её подтвердить.
+    pending_expires_at?: string | null;
+};
+
+export type BookingActor = "admin" | "client" | "system";
+
+export type ClientBooking = {
+    id: string;
+    date: ISODate;
+    start_time: TimeHHMM;
+    end_time: TimeHHMM;
+    duration_minutes: number;
+    client_name: string;
+    service_name?: string | null;
+    price_rub?: number | null;
+    status: BookingStatus;
+    pending_expires_at?: string | null;
+    created_at: string;
+    cancelled_at?: string | null;
+    cancel_source?: BookingActor;
+};
+
+export type RescheduleBookingRequest = {
+    date: ISODate;
+    start_time: TimeHHMM;
+};
+
+export type ClientRescheduleBookingRequest = RescheduleBookingRequest & {
+    token: string;
+};
+
+export type BookingReschedule = {
+    old_start_at: string;
+    old_end_at: string;
+    new_start_at: string;
+    new_end_at: string;
+    source: BookingActor;
+    rescheduled_by?: string | null;
+    created_at: string;
+};
+
+export type ClientCancelBookingRequest = {
+    token: string;
+    reason?: string | null;
+};
+
+export type BookingSummary = {
+    id: string;
+    date: ISODate;
+    start_time: TimeHHMM;
+    end_time: TimeHHMM;
+    duration_minutes: number;
+    client_name: string;
+    client_phone: string;
+    comment?: string | null;
+    status: BookingStatus;
+    pending_expires_at?: string | null;
+    created_at: string;
+    cancelled_at?: string | null;
+};
+
+export type BookingDetail = BookingSummary & {
+    confirmed_at?: string | null;
+    completed_at?: string | null;
+    no_show_at?: string | null;
+    cancel_reason?: string | null;
+    cancelled_by?: string | null;
+    cancel_source?: BookingActor;
+    client_id?: string | null;
+    reschedules?: BookingReschedule[];
+};
+
+export type Client = {
+    id: string;
+    name: string;
+    phone: string;
+    bookings_count: number;
+    visits_count: number;
+    no_show_count: number;
+    last_visit_at?: string | null;
+    total_spent_rub: number;
+    created_at: string;
+};
+
+export type AdminClientsResponse = {
+    items: Client[];
+};
+
+export type ClientMergeRequest = {
+    duplicate_ids: string[];
+};
+
+export type AdminBookingRequestsResponse = {
+    items: BookingSummary[];
+};
+
+export type AdminBookingsByDateResponse = {
+    date: ISODate;
+    items: BookingSummary[];
+};
+
+export type AdminSessionLoginRequest = {
+    username?: string;
+    password: string;
+};
+
+export type AdminRole = "owner" | "manager" | "viewer";
+
+export type AdminMeResponse = {
+    username: string;
+    role: AdminRole;
+    permissions: string[];
+};
+
+export type AdminLoginMFAResponse = {
+    mfa_required: boolean;
+};
+
+export type MfaCodeRequest = {
+    code?: string;
+    recovery_code?: string;
+};
+
+export type CancelBookingRequest = {
+    reason?: string | null;
+};
@@ -1,172 +1,325 @@
-import { useState } from "react";
-import Modal from "../../ui/Modal";
-import Text from "../../ui/Text";
-import Button from "../../ui/Button";
-import { Textarea } from "../../ui/Field";
-import { cn } from "../../ui/cn";
-import { AdminAPI, type BookingDetail } from "../../api";
-import { ApiError } from "../../api/http";
-
-export default function BookingDetailModal({
-                                               open,
-                                               onClose,
-                                               booking,
-                                               onUpdated,
-                                           }: {
-    open: boolean;
-    onClose: () => void;
-    booking: BookingDetail | null;
-    onUpdated: (updated: BookingDetail) => void;
-}) {
-    const [reason, setReason] = useState("");
-    const [confirming, setConfirming] = useState(false);
-    const [submitting, setSubmitting] = useState(false);
-    const [error, setError] = useState<string | null>(null);
-
-    if (!booking) return null;
-
-    const canCancel = booking.status === "active";
-
-    const doCancel = async () => {
-        setError(null);
-        setSubmitting(true);
-
-        try {
-            const updated = await AdminAPI.adminCancelBooking(booking.id, {
-                reason: reason.trim() ? reason.trim() : null,
-            });
-            onUpdated(updated);
-            setConfirming(false);
-            setSubmitting(false);
-        } catch (e) {
-            const ae = e instanceof ApiError ? e : new ApiError(0, "Unknown error");
-            setSubmitting(false);
-            setError(ae.message || "Failed to cancel");
-        }
-    };
-
-    return (
-        <Modal open={open} onClose={() => (!submitting ? onClose() : null)} width={620}>
-            <div className="flex items-start justify-between gap-4">
-                <div>
-                    <Text as="div" variant="h2">
-                        Booking details
-                    </Text>
-                    <Text variant="tiny" tone="muted" className="mt-[10px]">
-                        {booking.date} • {booking.start_time}–{booking.end_time} ({booking.duration_minutes} min)
-                    </Text>
-                </div>
-
-                <div
-                    className={cn(
-                        "inline-flex rounded-full border px-2 py-[2px] text-[10px] font-[900] tracking-[1.2px] mt-[4px]",
-                        booking.status === "active"
-                            ? "border-[#d7e7dc] bg-[#f0faf2] text-[#1f4d2b]"
-                            : "border-[#e8c9c9] bg-[#fcf1f1] text-[#6b1f1f]",
-                    )}
-                >
-                    {booking.status.toUpperCase()}
-                </div>
-            </div>
-
-            {error ? (
-                <div className="mt-[14px] rounded-[14px] border-[1.4px] border-[#e8c9c9] bg-[#fcf1f1] px-3 py-2 text-[#6b1f1f]">
-                    <Text variant="tiny" tone="ink" className="!text-inherit">
-                        {error}
-                    </Text>
-                </div>
-            ) : null}
-
-            <div className="mt-[16px] rounded-[16px] border-[1.4px] border-[var(--hair)] bg-white px-4 py-3">
-                <Text variant="small">Client</Text>
-                <Text variant="tiny" tone="muted" className="mt-[4px]">
-                    {booking.client_name} • {booking.client_phone}
-                </Text>
-
-                {booking.comment ? (
-                    <>
-                        <div className="h-[10px]" />
-                        <Text variant="small">Comment</Text>
-                        <Text variant="tiny" tone="muted" className="mt-[4px]">
-                            {booking.comment}
-                        </Text>
-                    </>
-                ) : null}
-
-                {booking.status === "cancelled" ? (
-                    <>
-                        <div className="h-[10px]" />
-                        <Text variant="small">Cancelled</Text>
-                        <Text variant="tiny" tone="muted" className="mt-[4px]">
-                            {booking.cancelled_at || "—"}
-                        </Text>
-
-                        {booking.cancel_reason ? (
-                            <>
-                                <div className="h-[10px]" />
-                                <Text variant="small">Reason</Text>
-                                <Text variant="tiny" tone="muted" className="mt-[4px]">
-                                    {booking.cancel_reason}
-                                </Text>
-                            </>
-                        ) : null}
-                    </>
-                ) : null}
-            </div>
-
-            <div className="mt-[14px]">
-                <Text variant="tiny" tone="muted">
-                    Cancel reason (optional)
-                </Text>
-                <div className="mt-[8px]">
-                    <Textarea
-                        value={reason}
-                        onChange={(e) => setReason(e.target.value)}
-                        placeholder="Reason (optional)"
-                    />
-                </div>
-            </div>
-
-            <div className="mt-[18px] flex gap-3">
-                <Button
-                    variant="ghost"
-                    className="flex-1"
-                    disabled={submitting}
-                    onClick={onClose}
-                >
-                    Close
-                </Button>
-
-                {canCancel ? (
-                    confirming ? (
-                        <Button
-                            className="flex-1"
-                            disabled={submitting}
-                            onClick={doCancel}
-                        >
-                            {submitting ? "Cancelling…" : "Confirm cancel"}
-                        </Button>
-                    ) : (
-                        <Button
-                            className="flex-1"
-                            disabled={submitting}
-                            onClick={() => setConfirming(true)}
-                        >
-                            Cancel booking
-                        </Button>
-                    )
-                ) : (
-                    <Button className="flex-1" disabled>
-                        Cancelled
-                    </Button>
-                )}
-            </div>
-
-            {canCancel && confirming ? (
-                <Text variant="tiny" tone="muted2" className="mt-[10px]">
-                    This action cannot be undone. Click “Confirm cancel” to proceed.
-                </Text>
-            ) : null}
-        </Modal>
-    );
-}
+import { useState } from "react";
+import Modal from "../../ui/Modal";
+import Text from "../../ui/Text";
+import Button from "../../ui/Button";
+import { Input, Textarea } from "../../ui/Field";
+import { AdminAPI, type BookingDetail } from "../../api";
+import { ApiError } from "../../api/http";
+import BookingStatusPill from "./BookingStatusPill";
+
+export default function BookingDetailModal({
+                                               open,
+                                               onClose,
+                                               booking,
+                                               onUpdated,
+                                               readOnly = false,
+                                           }: {
+    open: boolean;
+    onClose: () => void;
+    booking: BookingDetail | null;
+    onUpdated: (updated: BookingDetail) => void;
+    readOnly?: boolean;
+}) {
+    const [reason, setReason] = useState("");
+    const [confirming, setConfirming] = useState(false);
+    const [submitting, setSubmitting] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+    const [moveDate, setMoveDate] = useState("");
+    const [moveTime, setMoveTime] = useState("");
+
+    if (!booking) return null;
+
+    const canCancel = (booking.status === "pending" || booking.status === "confirmed") && !readOnly;
+    const canConfirm = booking.status === "pending" && !readOnly;
+    const canMark = booking.status === "confirmed" && !readOnly;
+
+    const doTransition = async (fn: (id: string) => Promise<BookingDetail>) => {
+        setError(null);
+        setSubmitting(true);
+
+        try {
+            onUpdated(await fn(booking.id));
+        } catch (e) {
+            const ae = e instanceof ApiError ? e : new ApiError(0, "Unknown error");
+            if (ae.status === 422 && ae.validationFields?.length) {
+                setError(ae.validationFields[0].message);
+            } else {
+                setError(ae.message || "Failed to update booking");
+            }
+        }
+        setSubmitting(false);
+    };
+
+    const doReject = async () => {
+        setError(null);
+        setSubmitting(true);
+
+        try {
+            onUpdated(
+                await AdminAPI.adminRejectBooking(booking.id, {
+                    reason: reason.trim() ? reason.trim() : null,
+                }),
+            );
+        } catch (e) {
+            const ae = e instanceof ApiError ? e : new ApiError(0, "Unknown error");
+            setError(ae.message || "Failed to reject");
+        }
+        setSubmitting(false);
+    };
+
+    const doCancel = async () => {
+        setError(null);
+        setSubmitting(true);
+
+        try {
+            const updated = await AdminAPI.adminCancelBooking(booking.id, {
+                reason: reason.trim() ? reason.trim() : null,
+            });
+            onUpdated(updated);
+            setConfirming(false);
+            setSubmitting(false);
+        } catch (e) {
+            const ae = e instanceof ApiError ? e : new ApiError(0, "Unknown error");
+            setSubmitting(false);
+            setError(ae.message || "Failed to cancel");
+        }
+    };
+
+    const doReschedule = async () => {
+        setError(null);
+        if (!moveDate || !moveTime) {
+            setError("Enter new date and time");
+            return;
+        }
+
+        setSubmitting(true);
+        try {
+            const updated = await AdminAPI.adminRescheduleBooking(booking.id, { date: moveDate, start_time: moveTime });
+            onUpdated(updated);
+            setMoveDate("");
+            setMoveTime("");
+        } catch (e) {
+            const ae = e instanceof ApiError ? e : new ApiError(0, "Unknown error");
+            if (ae.status === 409) {
+                setError("That time is already taken");
+            } else if (ae.status === 422 && ae.validationFields?.length) {
+                setError(ae.validationFields[0].message);
+            } else {
+                setError(ae.message || "Failed to reschedule");
+            }
+        }
+        setSubmitting(false);
+    };
+
+    return (
+        <Modal open={open} onClose={() => (!submitting ? onClose() : null)} width={620}>
+            <div className="flex items-start justify-between gap-4">
+                <div>
+                    <Text as="div" variant="h2">
+                        Booking details
+                    </Text>
+                    <Text variant="tiny" tone="muted" className="mt-[10px]">
+                        {booking.date} • {booking.start_time}–{booking.end_time} ({booking.duration_minutes} min)
+                    </Text>
+                </div>
+
+                <BookingStatusPill status={booking.status} className="mt-[4px]" />
+            </div>
+
+            {error ? (
+                <div className="mt-[14px] rounded-[14px] border-[1.4px] border-[#e8c9c9] bg-[#fcf1f1] px-3 py-2 text-[#6b1f1f]">
+                    <Text variant="tiny" tone="ink" className="!text-inherit">
+                        {error}
+                    </Text>
+                </div>
+            ) : null}
+
+            <div className="mt-[16px] rounded-[16px] border-[1.4px] border-[var(--hair)] bg-white px-4 py-3">
+                <Text variant="small">Client</Text>
+                <Text variant="tiny" tone="muted" className="mt-[4px]">
+                    {booking.client_name} • {booking.client_phone}
+                </Text>
+
+                {booking.comment ? (
+                    <>
+                        <div className="h-[10px]" />
+                        <Text variant="small">Comment</Text>
+                        <Text variant="tiny" tone="muted" className="mt-[4px]">
+                            {booking.comment}
+                        </Text>
+                    </>
+                ) : null}
+
+                {booking.status === "pending" && booking.pending_expires_at ? (
+                    <>
+                        <div className="h-[10px]" />
+                        <Text variant="small">Awaiting approval</Text>
+                        <Text variant="tiny" tone="muted" className="mt-[4px]">
+                            until {new Date(booking.pending_expires_at).toLocaleString()}
+                        </Text>
+                    </>
+                ) : null}
+
+                {booking.completed_at || booking.no_show_at ? (
+                    <>
+                        <div className="h-[10px]" />
+                        <Text variant="small">{booking.completed_at ? "Completed" : "No-show"}</Text>
+                        <Text variant="tiny" tone="muted" className="mt-[4px]">
+                            {booking.completed_at || booking.no_show_at}
+                        </Text>
+                    </>
+                ) : null}
+
+                {booking.status === "cancelled" ? (
+                    <>
+                        <div className="h-[10px]" />
+                        <Text variant="small">Cancelled</Text>
+                        <Text variant="tiny" tone="muted" className="mt-[4px]">
+                            {booking.cancelled_at || "—"}
+                            {booking.cancelled_by ? ` • by ${booking.cancelled_by}` : ""}
+                            {booking.cancel_source === "client" ? " • by client" : ""}
+                            {booking.cancel_source === "system" ? " • request expired" : ""}
+                        </Text>
+
+                        {booking.cancel_reason ? (
+                            <>
+                                <div className="h-[10px]" />
+                                <Text variant="small">Reason</Text>
+                                <Text variant="tiny" tone="muted" className="mt-[4px]">
+                                    {booking.cancel_reason}
+                                </Text>
+                            </>
+                        ) : null}
+                    </>
+                ) : null}
+
+                {booking.reschedules?.length ? (
+                    <>
+                        <div className="h-[10px]" />
+                        <Text variant="small">Rescheduled</Text>
+                        {booking.reschedules.map((rs) => (
+                            <Text key={rs.created_at} variant="tiny" tone="muted" className="mt-[4px]">
+                                {new Date(rs.old_start_at).toLocaleString()} → {new Date(rs.new_start_at).toLocaleString()}
+                                {rs.rescheduled_by ? ` • by ${rs.rescheduled_by}` : rs.source === "client" ? " • by client" : ""}
+                            </Text>
+                        ))}
+                    </>
+                ) : null}
+            </div>
+
+            {canConfirm || canMark ? (
+                <div className="mt-[14px] flex gap-3">
+                    {canConfirm ? (
+                        <>
+                            <Button
+                                variant="ghost"
+                                className="flex-1"
+                                disabled={submitting}
+                                onClick={() => doTransition(AdminAPI.adminApproveBooking)}
+                            >
+                                Approve request
+                            </Button>
+                            <Button
+                                variant="ghost"
+                                className="flex-1"
+                                disabled={submitting}
+                                onClick={doReject}
+                            >
+                                Reject
+                            </Button>
+                        </>
+                    ) : null}
+                    {canMark ? (
+                        <>
+                            <Button
+                                variant="ghost"
+                                className="flex-1"
+                                disabled={submitting}
+                                onClick={() => doTransition(AdminAPI.adminCompleteBooking)}
+                            >
+                                Completed
+                            </Button>
+                            <Button
+                                variant="ghost"
+                                className="flex-1"
+                                disabled={submitting}
+                                onClick={() => doTransition(AdminAPI.adminMarkNoShow)}
+                            >
+                                No-show
+                            </Button>
+                        </>
+                    ) : null}
+                </div>
+            ) : null}
+
+            {canCancel ? (
+                <div className="mt-[14px]">
+                    <Text variant="tiny" tone="muted">
+                        Move to another time
+                    </Text>
+                    <div className="mt-[8px] flex gap-3">
+                        <Input type="date" value={moveDate} onChange={(e) => setMoveDate(e.target.value)} />
+                        <Input type="time" step={600} value={moveTime} onChange={(e) => setMoveTime(e.target.value)} />
+                        <Button variant="ghost" disabled={submitting} onClick={doReschedule}>
+                            Move
+                        </Button>
+                    </div>
+                </div>
+            ) : null}
+
+            <div className="mt-[14px]">
+                <Text variant="tiny" tone="muted">
+                    Cancel reason (optional)
+                </Text>
+                <div className="mt-[8px]">
+                    <Textarea
+                        value={reason}
+                        onChange={(e) => setReason(e.target.value)}
+                        placeholder="Reason (optional)"
+                    />
+                </div>
+            </div>
+
+            <div className="mt-[18px] flex gap-3">
+                <Button
+                    variant="ghost"
+                    className="flex-1"
+                    disabled={submitting}
+                    onClick={onClose}
+                >
+                    Close
+                </Button>
+
+                {canCancel ? (
+                    confirming ? (
+                        <Button
+                            className="flex-1"
+                            disabled={submitting}
+                            onClick={doCancel}
+                        >
+                            {submitting ? "Cancelling…" : "Confirm cancel"}
+                        </Button>
+                    ) : (
+                        <Button
+                            className="flex-1"
+                            disabled={submitting}
+                            onClick={() => setConfirming(true)}
+                        >
+                            Cancel booking
+                        </Button>
+                    )
+                ) : (
+                    <Button className="flex-1" disabled>
+                        {booking.status === "cancelled" ? "Cancelled" : "Closed"}
+                    </Button>
+                )}
+            </div>
+
+            {canCancel && confirming ? (
+                <Text variant="tiny" tone="muted2" className="mt-[10px]">
+                    This action cannot be undone. Click “Confirm cancel” to proceed.
+                </Text>
+            ) : null}
+        </Modal>
+    );
+}
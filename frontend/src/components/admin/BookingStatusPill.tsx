@@ -0,0 +1,24 @@
+import { cn } from "../../ui/cn";
+import type { BookingStatus } from "../../api";
+
+const tones: Record<BookingStatus, string> = {
+    pending: "border-[#eadfb8] bg-[#fcf8ea] text-[#6b561f]",
+    confirmed: "border-[#d7e7dc] bg-[#f0faf2] text-[#1f4d2b]",
+    completed: "border-[var(--hair)] bg-white text-[var(--muted)]",
+    no_show: "border-[#e8c9c9] bg-[#fcf1f1] text-[#6b1f1f]",
+    cancelled: "border-[#e8c9c9] bg-[#fcf1f1] text-[#6b1f1f]",
+};
+
+export default function BookingStatusPill({ status, className }: { status: BookingStatus; className?: string }) {
+    return (
+        <div
+            className={cn(
+                "inline-flex rounded-full border px-2 py-[2px] text-[10px] font-[900] tracking-[1.2px]",
+                tones[status],
+                className,
+            )}
+        >
+            {status.replace("_", "-").toUpperCase()}
+        </div>
+    );
+}
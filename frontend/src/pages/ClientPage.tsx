@@ -14,7 +14,7 @@ import { addMinutesHHMM, buildSlotGrid } from "../utils/time";
 import { useFreeSlots } from "../hooks/useFreeSlots";
 
 import { ApiError } from "../api/http";
-import { PublicAPI, type BookingCreateRequest, type TimeHHMM } from "../api";
+import { PublicAPI, type BookingCreateRequest, type SlotHoldResponse, type TimeHHMM } from "../api";
 
 type SlotVariant = "free" | "busy" | "selected";
 
@@ -187,6 +187,40 @@ export default function ClientPage() {
     const [confirmOpen, setConfirmOpen] = useState(false);
     const [submitting, setSubmitting] = useState(false);
 
+    const [hold, setHold] = useState<SlotHoldResponse | null>(null);
+
+    const holdMatches = (h: SlotHoldResponse | null): h is SlotHoldResponse =>
+        !!h && h.date === date && h.start_time === selectedStartTime && h.duration_minutes === durationMinutes;
+
+    // Выбранное время держится за клиентом, пока он заполняет форму; пауза — чтобы не удерживать каждый клик.
+    useEffect(() => {
+        if (!selectedStartTime || holdMatches(hold)) return;
+
+        const timer = setTimeout(() => {
+            PublicAPI.createSlotHold({
+                date,
+                start_time: selectedStartTime,
+                duration_minutes: durationMinutes,
+                replace_hold_token: hold?.hold_token,
+            })
+                .then(setHold)
+                .catch((err) => {
+                    const ae = err instanceof ApiError ? err : new ApiError(0, "Unknown error");
+                    if (ae.status !== 409) return;
+
+                    setBanner({
+                        kind: "error",
+                        text: "Selected time has just been taken. Please choose another slot.",
+                    });
+                    setSlotsRefresh((v) => v + 1);
+                    setSelStartIndex(null);
+                    setSelCount(1);
+                });
+        }, 400);
+
+        return () => clearTimeout(timer);
+    }, [date, selectedStartTime, durationMinutes, hold]);
+
     const bookingHint = `Weekdays • ${RULES.slotMinutes}-minute grid • next ${RULES.bookingWindowDays} days`;
     const headerLine = `Studio • Mon–Fri ${RULES.workStart}–${RULES.workEnd}`;
 
@@ -233,16 +267,20 @@ export default function ClientPage() {
             phone: phone.trim(),
             comment: comment.trim() ? comment.trim() : null,
         };
+        if (holdMatches(hold) && new Date(hold.expires_at).getTime() > Date.now()) {
+            payload.hold_token = hold.hold_token;
+        }
 
         try {
             const res = await PublicAPI.createBooking(payload);
 
             setConfirmOpen(false);
             setSubmitting(false);
+            setHold(null);
 
             setBanner({
                 kind: "success",
-                text: `Booking created: ${res.date} ${res.start_time}–${res.end_time}`,
+                text: `${res.status === "pending" ? "Request sent, the studio will confirm it" : "Booking created"}: ${res.date} ${res.start_time}–${res.end_time}. Save this link to view or cancel it: ${PublicAPI.manageBookingUrl(res.id, res.manage_token)}`,
             });
 
             setSelStartIndex(null);
@@ -267,6 +305,12 @@ export default function ClientPage() {
                 return;
             }
 
+            if (ae.status === 422 && ae.validationFields?.some((f) => f.field === "hold_token")) {
+                setHold(null);
+                setBanner({ kind: "error", text: "Your hold on this time has expired. Please submit again." });
+                return;
+            }
+
             if (ae.status === 422 && ae.validationFields) {
                 const fe: FieldErrors = {};
                 for (const f of ae.validationFields) {
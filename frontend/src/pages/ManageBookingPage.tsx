@@ -0,0 +1,176 @@
+import { useEffect, useState } from "react";
+import { useParams, useSearchParams } from "react-router-dom";
+import Card from "../ui/Card.tsx";
+import Text from "../ui/Text.tsx";
+import Button from "../ui/Button.tsx";
+import { Input } from "../ui/Field.tsx";
+import { cn } from "../ui/cn.ts";
+import { ApiError, PublicAPI } from "../api";
+import type { ClientBooking } from "../api";
+
+// Страница по ссылке управления записью: клиент видит свою запись и может её отменить.
+export default function ManageBookingPage() {
+    const { id = "" } = useParams();
+    const [search] = useSearchParams();
+    const token = search.get("token") || "";
+
+    const [booking, setBooking] = useState<ClientBooking | null>(null);
+    const [reason, setReason] = useState("");
+    const [moveDate, setMoveDate] = useState("");
+    const [moveTime, setMoveTime] = useState("");
+    const [loading, setLoading] = useState(true);
+    const [submitting, setSubmitting] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+
+    useEffect(() => {
+        let alive = true;
+
+        if (!token) {
+            setError("The link is incomplete");
+            setLoading(false);
+            return;
+        }
+
+        PublicAPI.getClientBooking(id, token)
+            .then((b) => {
+                if (alive) setBooking(b);
+            })
+            .catch((e) => {
+                if (!alive) return;
+                const ae = e instanceof ApiError ? e : new ApiError(0, "Unknown error");
+                setError(ae.status === 404 ? "Booking not found or the link is invalid" : ae.message || "Failed to load booking");
+            })
+            .finally(() => {
+                if (alive) setLoading(false);
+            });
+
+        return () => {
+            alive = false;
+        };
+    }, [id, token]);
+
+    const cancel = async () => {
+        setError(null);
+        setSubmitting(true);
+        try {
+            const b = await PublicAPI.cancelClientBooking(id, {
+                token,
+                reason: reason.trim() ? reason.trim() : null,
+            });
+            setBooking(b);
+        } catch (e) {
+            const ae = e instanceof ApiError ? e : new ApiError(0, "Unknown error");
+            if (ae.status === 422 && ae.validationFields?.length) {
+                setError(ae.validationFields[0].message);
+            } else {
+                setError(ae.message || "Cancellation failed");
+            }
+        }
+        setSubmitting(false);
+    };
+
+    const reschedule = async () => {
+        setError(null);
+        if (!moveDate || !moveTime) {
+            setError("Choose new date and time");
+            return;
+        }
+
+        setSubmitting(true);
+        try {
+            const b = await PublicAPI.rescheduleClientBooking(id, { token, date: moveDate, start_time: moveTime });
+            setBooking(b);
+            setMoveDate("");
+            setMoveTime("");
+        } catch (e) {
+            const ae = e instanceof ApiError ? e : new ApiError(0, "Unknown error");
+            if (ae.status === 409) {
+                setError("That time is already taken, choose another one");
+            } else if (ae.status === 422 && ae.validationFields?.length) {
+                setError(ae.validationFields[0].message);
+            } else {
+                setError(ae.message || "Rescheduling failed");
+            }
+        }
+        setSubmitting(false);
+    };
+
+    const cancelled = booking?.status === "cancelled";
+    const open = booking?.status === "pending" || booking?.status === "confirmed";
+
+    return (
+        <div className="min-h-screen grid place-items-center px-6">
+            <div className="w-[520px]">
+                <Card shadow="strong" radius="var(--r-card)">
+                    <div className="p-[26px]">
+                        <Text as="div" variant="h2">
+                            Your booking
+                        </Text>
+
+                        {loading ? (
+                            <Text variant="tiny" tone="muted" className="mt-[10px]">
+                                Loading…
+                            </Text>
+                        ) : null}
+
+                        {error ? (
+                            <div
+                                className={cn(
+                                    "mt-[14px] rounded-[14px] border-[1.4px] border-[#e8c9c9] bg-[#fcf1f1] px-3 py-2 text-[#6b1f1f]",
+                                )}
+                            >
+                                <Text variant="tiny" tone="ink" className="!text-inherit">
+                                    {error}
+                                </Text>
+                            </div>
+                        ) : null}
+
+                        {booking ? (
+                            <div className="mt-[14px]">
+                                <Text variant="tiny" tone="ink">
+                                    {booking.date} {booking.start_time}–{booking.end_time}
+                                    {booking.service_name ? ` · ${booking.service_name}` : ""}
+                                </Text>
+                                <Text variant="tiny" tone="muted" className="mt-[6px]">
+                                    {cancelled
+                                        ? booking.cancel_source === "client"
+                                            ? "Cancelled by you"
+                                            : booking.cancel_source === "system"
+                                              ? "Request expired: the studio did not confirm it in time"
+                                              : "Cancelled by the studio"
+                                        : booking.status === "pending"
+                                          ? `Request for ${booking.client_name} is awaiting studio confirmation`
+                                          : `Booked for ${booking.client_name}`}
+                                </Text>
+                            </div>
+                        ) : null}
+
+                        {booking && open ? (
+                            <>
+                                <div className="mt-[16px] flex gap-3">
+                                    <Input type="date" value={moveDate} onChange={(e) => setMoveDate(e.target.value)} />
+                                    <Input type="time" value={moveTime} onChange={(e) => setMoveTime(e.target.value)} />
+                                    <Button variant="ghost" disabled={submitting} onClick={reschedule}>
+                                        Move
+                                    </Button>
+                                </div>
+                                <div className="mt-[16px]">
+                                    <Input
+                                        value={reason}
+                                        onChange={(e) => setReason(e.target.value)}
+                                        placeholder="Reason (optional)"
+                                    />
+                                </div>
+                                <div className="mt-[14px]">
+                                    <Button variant="dangerOutline" className="w-full" disabled={submitting} onClick={cancel}>
+                                        {submitting ? "Cancelling…" : "Cancel booking"}
+                                    </Button>
+                                </div>
+                            </>
+                        ) : null}
+                    </div>
+                </Card>
+            </div>
+        </div>
+    );
+}
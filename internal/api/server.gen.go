@@ -15,6 +15,33 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// API-ключи
+	// (GET /api/admin/api-keys)
+	AdminListApiKeys(w http.ResponseWriter, r *http.Request)
+	// Выпустить API-ключ
+	// (POST /api/admin/api-keys)
+	AdminCreateApiKey(w http.ResponseWriter, r *http.Request)
+	// Отозвать API-ключ
+	// (DELETE /api/admin/api-keys/{key_id})
+	AdminRevokeApiKey(w http.ResponseWriter, r *http.Request, keyId openapi_types.UUID)
+	// Блокировки времени на день
+	// (GET /api/admin/blocks)
+	AdminListBlocksByDate(w http.ResponseWriter, r *http.Request, params AdminListBlocksByDateParams)
+	// Заблокировать время
+	// (POST /api/admin/blocks)
+	AdminCreateBlock(w http.ResponseWriter, r *http.Request)
+	// Снять блокировку
+	// (DELETE /api/admin/blocks/{block_id})
+	AdminDeleteBlock(w http.ResponseWriter, r *http.Request, blockId openapi_types.UUID)
+	// Заявки, ждущие подтверждения
+	// (GET /api/admin/booking-requests)
+	AdminListBookingRequests(w http.ResponseWriter, r *http.Request)
+	// Одобрить заявку
+	// (POST /api/admin/booking-requests/{booking_id}/approve)
+	AdminApproveBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminApproveBookingParams)
+	// Отклонить заявку
+	// (POST /api/admin/booking-requests/{booking_id}/reject)
+	AdminRejectBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminRejectBookingParams)
 	// Записи на конкретный день
 	// (GET /api/admin/bookings)
 	AdminListBookingsByDate(w http.ResponseWriter, r *http.Request, params AdminListBookingsByDateParams)
@@ -24,15 +51,114 @@ type ServerInterface interface {
 	// Отменить запись
 	// (POST /api/admin/bookings/{booking_id}/cancel)
 	AdminCancelBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminCancelBookingParams)
+	// Отметить, что сеанс состоялся
+	// (POST /api/admin/bookings/{booking_id}/complete)
+	AdminCompleteBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminCompleteBookingParams)
+	// Подтвердить запись
+	// (POST /api/admin/bookings/{booking_id}/confirm)
+	AdminConfirmBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminConfirmBookingParams)
+	// Отметить неявку клиента
+	// (POST /api/admin/bookings/{booking_id}/no-show)
+	AdminMarkNoShow(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminMarkNoShowParams)
+	// Перенести запись
+	// (POST /api/admin/bookings/{booking_id}/reschedule)
+	AdminRescheduleBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminRescheduleBookingParams)
+	// Справочник клиентов
+	// (GET /api/admin/clients)
+	AdminListClients(w http.ResponseWriter, r *http.Request, params AdminListClientsParams)
+	// Карточка клиента
+	// (GET /api/admin/clients/{client_id})
+	AdminGetClient(w http.ResponseWriter, r *http.Request, clientId openapi_types.UUID)
+	// Слить дубликаты в клиента
+	// (POST /api/admin/clients/{client_id}/merge)
+	AdminMergeClients(w http.ResponseWriter, r *http.Request, clientId openapi_types.UUID, params AdminMergeClientsParams)
+	// Закрытия студии за период
+	// (GET /api/admin/closures)
+	AdminListClosures(w http.ResponseWriter, r *http.Request, params AdminListClosuresParams)
+	// Закрыть студию на период
+	// (POST /api/admin/closures)
+	AdminCreateClosure(w http.ResponseWriter, r *http.Request)
+	// Удалить закрытие
+	// (DELETE /api/admin/closures/{closure_id})
+	AdminDeleteClosure(w http.ResponseWriter, r *http.Request, closureId openapi_types.UUID)
+	// Журнал блокировок входа
+	// (GET /api/admin/login-lockouts)
+	AdminListLoginLockouts(w http.ResponseWriter, r *http.Request, params AdminListLoginLockoutsParams)
+	// Текущий администратор и его права
+	// (GET /api/admin/me)
+	AdminGetMe(w http.ResponseWriter, r *http.Request)
+	// Состояние второго фактора текущего администратора
+	// (GET /api/admin/mfa)
+	AdminGetMfaStatus(w http.ResponseWriter, r *http.Request)
+	// Выпустить новые коды восстановления
+	// (POST /api/admin/mfa/recovery-codes)
+	AdminRegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request)
+	// Начать подключение TOTP
+	// (POST /api/admin/mfa/totp)
+	AdminBeginTotp(w http.ResponseWriter, r *http.Request)
+	// Подтвердить TOTP первым кодом
+	// (POST /api/admin/mfa/totp/confirm)
+	AdminConfirmTotp(w http.ResponseWriter, r *http.Request)
+	// Отключить TOTP
+	// (POST /api/admin/mfa/totp/disable)
+	AdminDisableTotp(w http.ResponseWriter, r *http.Request)
+	// Вход через OpenID Connect (возврат от провайдера)
+	// (GET /api/admin/oidc/callback)
+	AdminOidcCallback(w http.ResponseWriter, r *http.Request, params AdminOidcCallbackParams)
+	// Вход через OpenID Connect (начало)
+	// (GET /api/admin/oidc/login)
+	AdminOidcLogin(w http.ResponseWriter, r *http.Request)
+	// Все услуги каталога
+	// (GET /api/admin/services)
+	AdminListServices(w http.ResponseWriter, r *http.Request)
+	// Добавить услугу
+	// (POST /api/admin/services)
+	AdminCreateService(w http.ResponseWriter, r *http.Request)
+	// Удалить услугу
+	// (DELETE /api/admin/services/{service_id})
+	AdminDeleteService(w http.ResponseWriter, r *http.Request, serviceId openapi_types.UUID)
+	// Изменить услугу
+	// (PUT /api/admin/services/{service_id})
+	AdminUpdateService(w http.ResponseWriter, r *http.Request, serviceId openapi_types.UUID)
 	// Вход администратора (установка session cookie)
 	// (POST /api/admin/session/login)
 	AdminSessionLogin(w http.ResponseWriter, r *http.Request)
+	// Выход администратора
+	// (POST /api/admin/session/logout)
+	AdminSessionLogout(w http.ResponseWriter, r *http.Request)
+	// Второй шаг входа (TOTP или код восстановления)
+	// (POST /api/admin/session/mfa)
+	AdminSessionMfa(w http.ResponseWriter, r *http.Request)
+	// Активные сессии администратора
+	// (GET /api/admin/sessions)
+	AdminListSessions(w http.ResponseWriter, r *http.Request)
+	// Отозвать сессию
+	// (DELETE /api/admin/sessions/{session_id})
+	AdminRevokeSession(w http.ResponseWriter, r *http.Request, sessionId openapi_types.UUID)
 	// Health-check
 	// (GET /api/health)
 	HealthCheck(w http.ResponseWriter, r *http.Request)
+	// Доступность по дням (для календаря)
+	// (GET /api/public/availability)
+	GetAvailability(w http.ResponseWriter, r *http.Request, params GetAvailabilityParams)
 	// Создать запись
 	// (POST /api/public/bookings)
 	CreateBooking(w http.ResponseWriter, r *http.Request, params CreateBookingParams)
+	// Запись клиента по ссылке управления
+	// (GET /api/public/bookings/{booking_id})
+	GetClientBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params GetClientBookingParams)
+	// Отмена записи клиентом
+	// (POST /api/public/bookings/{booking_id}/cancel)
+	CancelClientBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID)
+	// Перенос записи клиентом
+	// (POST /api/public/bookings/{booking_id}/reschedule)
+	RescheduleClientBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID)
+	// Удержать слот на время оформления записи
+	// (POST /api/public/holds)
+	CreateSlotHold(w http.ResponseWriter, r *http.Request, params CreateSlotHoldParams)
+	// Каталог услуг
+	// (GET /api/public/services)
+	ListPublicServices(w http.ResponseWriter, r *http.Request)
 	// Свободные слоты на дату
 	// (GET /api/public/slots)
 	GetFreeSlotsByDate(w http.ResponseWriter, r *http.Request, params GetFreeSlotsByDateParams)
@@ -42,6 +168,60 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
+// API-ключи
+// (GET /api/admin/api-keys)
+func (_ Unimplemented) AdminListApiKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выпустить API-ключ
+// (POST /api/admin/api-keys)
+func (_ Unimplemented) AdminCreateApiKey(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать API-ключ
+// (DELETE /api/admin/api-keys/{key_id})
+func (_ Unimplemented) AdminRevokeApiKey(w http.ResponseWriter, r *http.Request, keyId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Блокировки времени на день
+// (GET /api/admin/blocks)
+func (_ Unimplemented) AdminListBlocksByDate(w http.ResponseWriter, r *http.Request, params AdminListBlocksByDateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Заблокировать время
+// (POST /api/admin/blocks)
+func (_ Unimplemented) AdminCreateBlock(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Снять блокировку
+// (DELETE /api/admin/blocks/{block_id})
+func (_ Unimplemented) AdminDeleteBlock(w http.ResponseWriter, r *http.Request, blockId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Заявки, ждущие подтверждения
+// (GET /api/admin/booking-requests)
+func (_ Unimplemented) AdminListBookingRequests(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Одобрить заявку
+// (POST /api/admin/booking-requests/{booking_id}/approve)
+func (_ Unimplemented) AdminApproveBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminApproveBookingParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отклонить заявку
+// (POST /api/admin/booking-requests/{booking_id}/reject)
+func (_ Unimplemented) AdminRejectBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminRejectBookingParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Записи на конкретный день
 // (GET /api/admin/bookings)
 func (_ Unimplemented) AdminListBookingsByDate(w http.ResponseWriter, r *http.Request, params AdminListBookingsByDateParams) {
@@ -60,78 +240,1715 @@ func (_ Unimplemented) AdminCancelBooking(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Отметить, что сеанс состоялся
+// (POST /api/admin/bookings/{booking_id}/complete)
+func (_ Unimplemented) AdminCompleteBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminCompleteBookingParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Подтвердить запись
+// (POST /api/admin/bookings/{booking_id}/confirm)
+func (_ Unimplemented) AdminConfirmBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminConfirmBookingParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отметить неявку клиента
+// (POST /api/admin/bookings/{booking_id}/no-show)
+func (_ Unimplemented) AdminMarkNoShow(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminMarkNoShowParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Перенести запись
+// (POST /api/admin/bookings/{booking_id}/reschedule)
+func (_ Unimplemented) AdminRescheduleBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params AdminRescheduleBookingParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Справочник клиентов
+// (GET /api/admin/clients)
+func (_ Unimplemented) AdminListClients(w http.ResponseWriter, r *http.Request, params AdminListClientsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Карточка клиента
+// (GET /api/admin/clients/{client_id})
+func (_ Unimplemented) AdminGetClient(w http.ResponseWriter, r *http.Request, clientId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Слить дубликаты в клиента
+// (POST /api/admin/clients/{client_id}/merge)
+func (_ Unimplemented) AdminMergeClients(w http.ResponseWriter, r *http.Request, clientId openapi_types.UUID, params AdminMergeClientsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Закрытия студии за период
+// (GET /api/admin/closures)
+func (_ Unimplemented) AdminListClosures(w http.ResponseWriter, r *http.Request, params AdminListClosuresParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Закрыть студию на период
+// (POST /api/admin/closures)
+func (_ Unimplemented) AdminCreateClosure(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить закрытие
+// (DELETE /api/admin/closures/{closure_id})
+func (_ Unimplemented) AdminDeleteClosure(w http.ResponseWriter, r *http.Request, closureId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Журнал блокировок входа
+// (GET /api/admin/login-lockouts)
+func (_ Unimplemented) AdminListLoginLockouts(w http.ResponseWriter, r *http.Request, params AdminListLoginLockoutsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Текущий администратор и его права
+// (GET /api/admin/me)
+func (_ Unimplemented) AdminGetMe(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Состояние второго фактора текущего администратора
+// (GET /api/admin/mfa)
+func (_ Unimplemented) AdminGetMfaStatus(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выпустить новые коды восстановления
+// (POST /api/admin/mfa/recovery-codes)
+func (_ Unimplemented) AdminRegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Начать подключение TOTP
+// (POST /api/admin/mfa/totp)
+func (_ Unimplemented) AdminBeginTotp(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Подтвердить TOTP первым кодом
+// (POST /api/admin/mfa/totp/confirm)
+func (_ Unimplemented) AdminConfirmTotp(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отключить TOTP
+// (POST /api/admin/mfa/totp/disable)
+func (_ Unimplemented) AdminDisableTotp(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Вход через OpenID Connect (возврат от провайдера)
+// (GET /api/admin/oidc/callback)
+func (_ Unimplemented) AdminOidcCallback(w http.ResponseWriter, r *http.Request, params AdminOidcCallbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Вход через OpenID Connect (начало)
+// (GET /api/admin/oidc/login)
+func (_ Unimplemented) AdminOidcLogin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Все услуги каталога
+// (GET /api/admin/services)
+func (_ Unimplemented) AdminListServices(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Добавить услугу
+// (POST /api/admin/services)
+func (_ Unimplemented) AdminCreateService(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить услугу
+// (DELETE /api/admin/services/{service_id})
+func (_ Unimplemented) AdminDeleteService(w http.ResponseWriter, r *http.Request, serviceId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Изменить услугу
+// (PUT /api/admin/services/{service_id})
+func (_ Unimplemented) AdminUpdateService(w http.ResponseWriter, r *http.Request, serviceId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Вход администратора (установка session cookie)
 // (POST /api/admin/session/login)
 func (_ Unimplemented) AdminSessionLogin(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Выход администратора
+// (POST /api/admin/session/logout)
+func (_ Unimplemented) AdminSessionLogout(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Второй шаг входа (TOTP или код восстановления)
+// (POST /api/admin/session/mfa)
+func (_ Unimplemented) AdminSessionMfa(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Активные сессии администратора
+// (GET /api/admin/sessions)
+func (_ Unimplemented) AdminListSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать сессию
+// (DELETE /api/admin/sessions/{session_id})
+func (_ Unimplemented) AdminRevokeSession(w http.ResponseWriter, r *http.Request, sessionId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Health-check
 // (GET /api/health)
 func (_ Unimplemented) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Доступность по дням (для календаря)
+// (GET /api/public/availability)
+func (_ Unimplemented) GetAvailability(w http.ResponseWriter, r *http.Request, params GetAvailabilityParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Создать запись
 // (POST /api/public/bookings)
 func (_ Unimplemented) CreateBooking(w http.ResponseWriter, r *http.Request, params CreateBookingParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Свободные слоты на дату
-// (GET /api/public/slots)
-func (_ Unimplemented) GetFreeSlotsByDate(w http.ResponseWriter, r *http.Request, params GetFreeSlotsByDateParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// Запись клиента по ссылке управления
+// (GET /api/public/bookings/{booking_id})
+func (_ Unimplemented) GetClientBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params GetClientBookingParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отмена записи клиентом
+// (POST /api/public/bookings/{booking_id}/cancel)
+func (_ Unimplemented) CancelClientBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Перенос записи клиентом
+// (POST /api/public/bookings/{booking_id}/reschedule)
+func (_ Unimplemented) RescheduleClientBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удержать слот на время оформления записи
+// (POST /api/public/holds)
+func (_ Unimplemented) CreateSlotHold(w http.ResponseWriter, r *http.Request, params CreateSlotHoldParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Каталог услуг
+// (GET /api/public/services)
+func (_ Unimplemented) ListPublicServices(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Свободные слоты на дату
+// (GET /api/public/slots)
+func (_ Unimplemented) GetFreeSlotsByDate(w http.ResponseWriter, r *http.Request, params GetFreeSlotsByDateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// AdminListApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) AdminListApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminCreateApiKey operation middleware
+func (siw *ServerInterfaceWrapper) AdminCreateApiKey(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminCreateApiKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminRevokeApiKey operation middleware
+func (siw *ServerInterfaceWrapper) AdminRevokeApiKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "key_id" -------------
+	var keyId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key_id", chi.URLParam(r, "key_id"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRevokeApiKey(w, r, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminListBlocksByDate operation middleware
+func (siw *ServerInterfaceWrapper) AdminListBlocksByDate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminListBlocksByDateParams
+
+	// ------------- Required query parameter "date" -------------
+
+	if paramValue := r.URL.Query().Get("date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "date"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListBlocksByDate(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminCreateBlock operation middleware
+func (siw *ServerInterfaceWrapper) AdminCreateBlock(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminCreateBlock(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminDeleteBlock operation middleware
+func (siw *ServerInterfaceWrapper) AdminDeleteBlock(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "block_id" -------------
+	var blockId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "block_id", chi.URLParam(r, "block_id"), &blockId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "block_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminDeleteBlock(w, r, blockId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminListBookingRequests operation middleware
+func (siw *ServerInterfaceWrapper) AdminListBookingRequests(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListBookingRequests(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminApproveBooking operation middleware
+func (siw *ServerInterfaceWrapper) AdminApproveBooking(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminApproveBookingParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminApproveBooking(w, r, bookingId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminRejectBooking operation middleware
+func (siw *ServerInterfaceWrapper) AdminRejectBooking(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminRejectBookingParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRejectBooking(w, r, bookingId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminListBookingsByDate operation middleware
+func (siw *ServerInterfaceWrapper) AdminListBookingsByDate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminListBookingsByDateParams
+
+	// ------------- Required query parameter "date" -------------
+
+	if paramValue := r.URL.Query().Get("date"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "date"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListBookingsByDate(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminGetBooking operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetBooking(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetBooking(w, r, bookingId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminCancelBooking operation middleware
+func (siw *ServerInterfaceWrapper) AdminCancelBooking(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminCancelBookingParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminCancelBooking(w, r, bookingId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminCompleteBooking operation middleware
+func (siw *ServerInterfaceWrapper) AdminCompleteBooking(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminCompleteBookingParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminCompleteBooking(w, r, bookingId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminConfirmBooking operation middleware
+func (siw *ServerInterfaceWrapper) AdminConfirmBooking(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminConfirmBookingParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminConfirmBooking(w, r, bookingId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminMarkNoShow operation middleware
+func (siw *ServerInterfaceWrapper) AdminMarkNoShow(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminMarkNoShowParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminMarkNoShow(w, r, bookingId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminRescheduleBooking operation middleware
+func (siw *ServerInterfaceWrapper) AdminRescheduleBooking(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminRescheduleBookingParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRescheduleBooking(w, r, bookingId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminListClients operation middleware
+func (siw *ServerInterfaceWrapper) AdminListClients(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminListClientsParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListClients(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminGetClient operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetClient(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "client_id" -------------
+	var clientId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "client_id", chi.URLParam(r, "client_id"), &clientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "client_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetClient(w, r, clientId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminMergeClients operation middleware
+func (siw *ServerInterfaceWrapper) AdminMergeClients(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "client_id" -------------
+	var clientId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "client_id", chi.URLParam(r, "client_id"), &clientId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "client_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminMergeClientsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminMergeClients(w, r, clientId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminListClosures operation middleware
+func (siw *ServerInterfaceWrapper) AdminListClosures(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminListClosuresParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListClosures(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminCreateClosure operation middleware
+func (siw *ServerInterfaceWrapper) AdminCreateClosure(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminCreateClosure(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminDeleteClosure operation middleware
+func (siw *ServerInterfaceWrapper) AdminDeleteClosure(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "closure_id" -------------
+	var closureId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "closure_id", chi.URLParam(r, "closure_id"), &closureId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "closure_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminDeleteClosure(w, r, closureId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminListLoginLockouts operation middleware
+func (siw *ServerInterfaceWrapper) AdminListLoginLockouts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminListLoginLockoutsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListLoginLockouts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminGetMe operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetMe(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetMe(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminGetMfaStatus operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetMfaStatus(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetMfaStatus(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminRegenerateRecoveryCodes operation middleware
+func (siw *ServerInterfaceWrapper) AdminRegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRegenerateRecoveryCodes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminBeginTotp operation middleware
+func (siw *ServerInterfaceWrapper) AdminBeginTotp(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminBeginTotp(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminConfirmTotp operation middleware
+func (siw *ServerInterfaceWrapper) AdminConfirmTotp(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminConfirmTotp(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminDisableTotp operation middleware
+func (siw *ServerInterfaceWrapper) AdminDisableTotp(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminDisableTotp(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminOidcCallback operation middleware
+func (siw *ServerInterfaceWrapper) AdminOidcCallback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminOidcCallbackParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", r.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "error", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminOidcCallback(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminOidcLogin operation middleware
+func (siw *ServerInterfaceWrapper) AdminOidcLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminOidcLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminListServices operation middleware
+func (siw *ServerInterfaceWrapper) AdminListServices(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListServices(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminCreateService operation middleware
+func (siw *ServerInterfaceWrapper) AdminCreateService(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminCreateService(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminDeleteService operation middleware
+func (siw *ServerInterfaceWrapper) AdminDeleteService(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "service_id" -------------
+	var serviceId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service_id", chi.URLParam(r, "service_id"), &serviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminDeleteService(w, r, serviceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminUpdateService operation middleware
+func (siw *ServerInterfaceWrapper) AdminUpdateService(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "service_id" -------------
+	var serviceId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service_id", chi.URLParam(r, "service_id"), &serviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminUpdateService(w, r, serviceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminSessionLogin operation middleware
+func (siw *ServerInterfaceWrapper) AdminSessionLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminSessionLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminSessionLogout operation middleware
+func (siw *ServerInterfaceWrapper) AdminSessionLogout(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminSessionLogout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminSessionMfa operation middleware
+func (siw *ServerInterfaceWrapper) AdminSessionMfa(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminSessionMfa(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminListSessions operation middleware
+func (siw *ServerInterfaceWrapper) AdminListSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminRevokeSession operation middleware
+func (siw *ServerInterfaceWrapper) AdminRevokeSession(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "session_id" -------------
+	var sessionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "session_id", chi.URLParam(r, "session_id"), &sessionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "session_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, CsrfTokenScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRevokeSession(w, r, sessionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+// HealthCheck operation middleware
+func (siw *ServerInterfaceWrapper) HealthCheck(w http.ResponseWriter, r *http.Request) {
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.HealthCheck(w, r)
+	}))
 
-// AdminListBookingsByDate operation middleware
-func (siw *ServerInterfaceWrapper) AdminListBookingsByDate(w http.ResponseWriter, r *http.Request) {
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAvailability operation middleware
+func (siw *ServerInterfaceWrapper) GetAvailability(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	ctx := r.Context()
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAvailabilityParams
 
-	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+	// ------------- Required query parameter "from" -------------
 
-	r = r.WithContext(ctx)
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params AdminListBookingsByDateParams
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
 
-	// ------------- Required query parameter "date" -------------
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
 
-	if paramValue := r.URL.Query().Get("date"); paramValue != "" {
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
 
 	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "date"})
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "date", r.URL.Query(), &params.Date)
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "status" -------------
+	// ------------- Optional query parameter "duration_minutes" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	err = runtime.BindQueryParameter("form", true, false, "duration_minutes", r.URL.Query(), &params.DurationMinutes)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "duration_minutes", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "service_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_id", r.URL.Query(), &params.ServiceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AdminListBookingsByDate(w, r, params)
+		siw.Handler.GetAvailability(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -141,28 +1958,37 @@ func (siw *ServerInterfaceWrapper) AdminListBookingsByDate(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// AdminGetBooking operation middleware
-func (siw *ServerInterfaceWrapper) AdminGetBooking(w http.ResponseWriter, r *http.Request) {
+// CreateBooking operation middleware
+func (siw *ServerInterfaceWrapper) CreateBooking(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "booking_id" -------------
-	var bookingId openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateBookingParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
-		return
-	}
+	headers := r.Header
 
-	ctx := r.Context()
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
 
-	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
 
-	r = r.WithContext(ctx)
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AdminGetBooking(w, r, bookingId)
+		siw.Handler.CreateBooking(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -172,8 +1998,8 @@ func (siw *ServerInterfaceWrapper) AdminGetBooking(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// AdminCancelBooking operation middleware
-func (siw *ServerInterfaceWrapper) AdminCancelBooking(w http.ResponseWriter, r *http.Request) {
+// GetClientBooking operation middleware
+func (siw *ServerInterfaceWrapper) GetClientBooking(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -186,38 +2012,26 @@ func (siw *ServerInterfaceWrapper) AdminCancelBooking(w http.ResponseWriter, r *
 		return
 	}
 
-	ctx := r.Context()
-
-	ctx = context.WithValue(ctx, CookieAuthScopes, []string{})
-
-	r = r.WithContext(ctx)
-
 	// Parameter object where we will unmarshal all parameters from the context
-	var params AdminCancelBookingParams
-
-	headers := r.Header
+	var params GetClientBookingParams
 
-	// ------------- Optional header parameter "Idempotency-Key" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
-		var IdempotencyKey IdempotencyKey
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
-			return
-		}
+	// ------------- Required query parameter "token" -------------
 
-		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
-			return
-		}
+	if paramValue := r.URL.Query().Get("token"); paramValue != "" {
 
-		params.IdempotencyKey = &IdempotencyKey
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "token"})
+		return
+	}
 
+	err = runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AdminCancelBooking(w, r, bookingId, params)
+		siw.Handler.GetClientBooking(w, r, bookingId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -227,11 +2041,22 @@ func (siw *ServerInterfaceWrapper) AdminCancelBooking(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// AdminSessionLogin operation middleware
-func (siw *ServerInterfaceWrapper) AdminSessionLogin(w http.ResponseWriter, r *http.Request) {
+// CancelClientBooking operation middleware
+func (siw *ServerInterfaceWrapper) CancelClientBooking(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AdminSessionLogin(w, r)
+		siw.Handler.CancelClientBooking(w, r, bookingId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -241,11 +2066,22 @@ func (siw *ServerInterfaceWrapper) AdminSessionLogin(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// HealthCheck operation middleware
-func (siw *ServerInterfaceWrapper) HealthCheck(w http.ResponseWriter, r *http.Request) {
+// RescheduleClientBooking operation middleware
+func (siw *ServerInterfaceWrapper) RescheduleClientBooking(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "booking_id" -------------
+	var bookingId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "booking_id", chi.URLParam(r, "booking_id"), &bookingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "booking_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.HealthCheck(w, r)
+		siw.Handler.RescheduleClientBooking(w, r, bookingId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -255,13 +2091,13 @@ func (siw *ServerInterfaceWrapper) HealthCheck(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// CreateBooking operation middleware
-func (siw *ServerInterfaceWrapper) CreateBooking(w http.ResponseWriter, r *http.Request) {
+// CreateSlotHold operation middleware
+func (siw *ServerInterfaceWrapper) CreateSlotHold(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params CreateBookingParams
+	var params CreateSlotHoldParams
 
 	headers := r.Header
 
@@ -285,7 +2121,21 @@ func (siw *ServerInterfaceWrapper) CreateBooking(w http.ResponseWriter, r *http.
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateBooking(w, r, params)
+		siw.Handler.CreateSlotHold(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPublicServices operation middleware
+func (siw *ServerInterfaceWrapper) ListPublicServices(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPublicServices(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -318,6 +2168,22 @@ func (siw *ServerInterfaceWrapper) GetFreeSlotsByDate(w http.ResponseWriter, r *
 		return
 	}
 
+	// ------------- Optional query parameter "duration_minutes" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "duration_minutes", r.URL.Query(), &params.DurationMinutes)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "duration_minutes", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "service_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_id", r.URL.Query(), &params.ServiceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "service_id", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetFreeSlotsByDate(w, r, params)
 	}))
@@ -442,6 +2308,33 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/api-keys", wrapper.AdminListApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/api-keys", wrapper.AdminCreateApiKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/api-keys/{key_id}", wrapper.AdminRevokeApiKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/blocks", wrapper.AdminListBlocksByDate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/blocks", wrapper.AdminCreateBlock)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/blocks/{block_id}", wrapper.AdminDeleteBlock)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/booking-requests", wrapper.AdminListBookingRequests)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/booking-requests/{booking_id}/approve", wrapper.AdminApproveBooking)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/booking-requests/{booking_id}/reject", wrapper.AdminRejectBooking)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/admin/bookings", wrapper.AdminListBookingsByDate)
 	})
@@ -451,15 +2344,114 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/admin/bookings/{booking_id}/cancel", wrapper.AdminCancelBooking)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/bookings/{booking_id}/complete", wrapper.AdminCompleteBooking)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/bookings/{booking_id}/confirm", wrapper.AdminConfirmBooking)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/bookings/{booking_id}/no-show", wrapper.AdminMarkNoShow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/bookings/{booking_id}/reschedule", wrapper.AdminRescheduleBooking)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/clients", wrapper.AdminListClients)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/clients/{client_id}", wrapper.AdminGetClient)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/clients/{client_id}/merge", wrapper.AdminMergeClients)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/closures", wrapper.AdminListClosures)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/closures", wrapper.AdminCreateClosure)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/closures/{closure_id}", wrapper.AdminDeleteClosure)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/login-lockouts", wrapper.AdminListLoginLockouts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/me", wrapper.AdminGetMe)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/mfa", wrapper.AdminGetMfaStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/mfa/recovery-codes", wrapper.AdminRegenerateRecoveryCodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/mfa/totp", wrapper.AdminBeginTotp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/mfa/totp/confirm", wrapper.AdminConfirmTotp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/mfa/totp/disable", wrapper.AdminDisableTotp)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/oidc/callback", wrapper.AdminOidcCallback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/oidc/login", wrapper.AdminOidcLogin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/services", wrapper.AdminListServices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/services", wrapper.AdminCreateService)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/services/{service_id}", wrapper.AdminDeleteService)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/admin/services/{service_id}", wrapper.AdminUpdateService)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/admin/session/login", wrapper.AdminSessionLogin)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/session/logout", wrapper.AdminSessionLogout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/session/mfa", wrapper.AdminSessionMfa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/sessions", wrapper.AdminListSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/sessions/{session_id}", wrapper.AdminRevokeSession)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/health", wrapper.HealthCheck)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/public/availability", wrapper.GetAvailability)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/public/bookings", wrapper.CreateBooking)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/public/bookings/{booking_id}", wrapper.GetClientBooking)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/public/bookings/{booking_id}/cancel", wrapper.CancelClientBooking)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/public/bookings/{booking_id}/reschedule", wrapper.RescheduleClientBooking)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/public/holds", wrapper.CreateSlotHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/public/services", wrapper.ListPublicServices)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/public/slots", wrapper.GetFreeSlotsByDate)
 	})
@@ -10,13 +10,32 @@ import (
 )
 
 const (
+	BearerAuthScopes = "bearerAuth.Scopes"
 	CookieAuthScopes = "cookieAuth.Scopes"
+	CsrfTokenScopes  = "csrfToken.Scopes"
+)
+
+// Defines values for AdminMeResponseRole.
+const (
+	AdminMeResponseRoleManager AdminMeResponseRole = "manager"
+	AdminMeResponseRoleOwner   AdminMeResponseRole = "owner"
+	AdminMeResponseRoleViewer  AdminMeResponseRole = "viewer"
+)
+
+// Defines values for BookingActor.
+const (
+	BookingActorAdmin  BookingActor = "admin"
+	BookingActorClient BookingActor = "client"
+	BookingActorSystem BookingActor = "system"
 )
 
 // Defines values for BookingStatus.
 const (
-	BookingStatusActive    BookingStatus = "active"
 	BookingStatusCancelled BookingStatus = "cancelled"
+	BookingStatusCompleted BookingStatus = "completed"
+	BookingStatusConfirmed BookingStatus = "confirmed"
+	BookingStatusNoShow    BookingStatus = "no_show"
+	BookingStatusPending   BookingStatus = "pending"
 )
 
 // Defines values for BookingStatusFilter.
@@ -24,6 +43,23 @@ const (
 	BookingStatusFilterActive    BookingStatusFilter = "active"
 	BookingStatusFilterAll       BookingStatusFilter = "all"
 	BookingStatusFilterCancelled BookingStatusFilter = "cancelled"
+	BookingStatusFilterCompleted BookingStatusFilter = "completed"
+	BookingStatusFilterConfirmed BookingStatusFilter = "confirmed"
+	BookingStatusFilterNoShow    BookingStatusFilter = "no_show"
+	BookingStatusFilterPending   BookingStatusFilter = "pending"
+)
+
+// Defines values for DayAvailabilityStatus.
+const (
+	DayAvailabilityStatusAvailable DayAvailabilityStatus = "available"
+	DayAvailabilityStatusClosed    DayAvailabilityStatus = "closed"
+	DayAvailabilityStatusFull      DayAvailabilityStatus = "full"
+)
+
+// Defines values for LoginLockoutScope.
+const (
+	LoginLockoutScopeIp       LoginLockoutScope = "ip"
+	LoginLockoutScopeUsername LoginLockoutScope = "username"
 )
 
 // Defines values for ValidationErrorResponseCode.
@@ -31,17 +67,160 @@ const (
 	ValidationErrorResponseCodeValidationError ValidationErrorResponseCode = "validation_error"
 )
 
+// AdminBlocksByDateResponse defines model for AdminBlocksByDateResponse.
+type AdminBlocksByDateResponse struct {
+	Date  openapi_types.Date `json:"date"`
+	Items []Block            `json:"items"`
+}
+
+// AdminBookingRequestsResponse defines model for AdminBookingRequestsResponse.
+type AdminBookingRequestsResponse struct {
+	Items []BookingSummary `json:"items"`
+}
+
 // AdminBookingsByDateResponse defines model for AdminBookingsByDateResponse.
 type AdminBookingsByDateResponse struct {
 	Date  openapi_types.Date `json:"date"`
 	Items []BookingSummary   `json:"items"`
 }
 
+// AdminClientsResponse defines model for AdminClientsResponse.
+type AdminClientsResponse struct {
+	Items []Client `json:"items"`
+}
+
+// AdminClosuresResponse defines model for AdminClosuresResponse.
+type AdminClosuresResponse struct {
+	Items []Closure `json:"items"`
+}
+
+// AdminLoginLockoutsResponse defines model for AdminLoginLockoutsResponse.
+type AdminLoginLockoutsResponse struct {
+	Items []LoginLockout `json:"items"`
+}
+
+// AdminLoginMFAResponse defines model for AdminLoginMFAResponse.
+type AdminLoginMFAResponse struct {
+	MfaRequired bool `json:"mfa_required"`
+}
+
+// AdminMeResponse defines model for AdminMeResponse.
+type AdminMeResponse struct {
+	// Permissions schedule:read, schedule:write, clients:pii, admins:manage
+	Permissions []string            `json:"permissions"`
+	Role        AdminMeResponseRole `json:"role"`
+	Username    string              `json:"username"`
+}
+
+// AdminMeResponseRole defines model for AdminMeResponse.Role.
+type AdminMeResponseRole string
+
+// AdminSession defines model for AdminSession.
+type AdminSession struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Current Сессия, с которой выполнен запрос
+	Current bool `json:"current"`
+
+	// ExpiresAt Абсолютный срок сессии (без учёта простоя)
+	ExpiresAt  time.Time          `json:"expires_at"`
+	Id         openapi_types.UUID `json:"id"`
+	Ip         string             `json:"ip"`
+	LastSeenAt time.Time          `json:"last_seen_at"`
+	UserAgent  string             `json:"user_agent"`
+	Username   string             `json:"username"`
+}
+
 // AdminSessionLoginRequest defines model for AdminSessionLoginRequest.
 type AdminSessionLoginRequest struct {
 	Password string `json:"password"`
+
+	// Username Логин администратора; если не передан — admin
+	Username *string `json:"username,omitempty"`
+}
+
+// AdminSessionsResponse defines model for AdminSessionsResponse.
+type AdminSessionsResponse struct {
+	Items []AdminSession `json:"items"`
+}
+
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// CreatedBy Логин администратора, от имени которого действует ключ
+	CreatedBy  string             `json:"created_by"`
+	ExpiresAt  *time.Time         `json:"expires_at"`
+	Id         openapi_types.UUID `json:"id"`
+	LastUsedAt *time.Time         `json:"last_used_at"`
+	Name       string             `json:"name"`
+
+	// Prefix Начало ключа, чтобы узнать его в конфигурации
+	Prefix    string     `json:"prefix"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	Scopes    []string   `json:"scopes"`
+}
+
+// ApiKeyCreateRequest defines model for ApiKeyCreateRequest.
+type ApiKeyCreateRequest struct {
+	// ExpiresAt Без срока — ключ бессрочный
+	ExpiresAt *time.Time `json:"expires_at"`
+	Name      string     `json:"name"`
+
+	// Scopes schedule:read, schedule:write, clients:pii, admins:manage — не шире роли создающего
+	Scopes []string `json:"scopes"`
+}
+
+// ApiKeyCreateResponse defines model for ApiKeyCreateResponse.
+type ApiKeyCreateResponse struct {
+	ApiKey ApiKey `json:"api_key"`
+
+	// Key Ключ целиком; показывается один раз
+	Key string `json:"key"`
+}
+
+// ApiKeysResponse defines model for ApiKeysResponse.
+type ApiKeysResponse struct {
+	Items []ApiKey `json:"items"`
+}
+
+// AvailabilityResponse defines model for AvailabilityResponse.
+type AvailabilityResponse struct {
+	Days            []DayAvailability  `json:"days"`
+	DurationMinutes int                `json:"duration_minutes"`
+	From            openapi_types.Date `json:"from"`
+	To              openapi_types.Date `json:"to"`
+}
+
+// Block defines model for Block.
+type Block struct {
+	CreatedAt       time.Time          `json:"created_at"`
+	Date            openapi_types.Date `json:"date"`
+	DurationMinutes int                `json:"duration_minutes"`
+
+	// EndTime Окончание (не включительно)
+	EndTime   string             `json:"end_time"`
+	Id        openapi_types.UUID `json:"id"`
+	Reason    *string            `json:"reason"`
+	StartTime string             `json:"start_time"`
+}
+
+// BlockCreateRequest defines model for BlockCreateRequest.
+type BlockCreateRequest struct {
+	// Date Дата блокировки (TZ студии)
+	Date openapi_types.Date `json:"date"`
+
+	// DurationMinutes Длительность (кратно slot_minutes), интервал не должен переходить через полночь
+	DurationMinutes int     `json:"duration_minutes"`
+	Reason          *string `json:"reason"`
+
+	// StartTime Время начала (кратно slot_minutes)
+	StartTime string `json:"start_time"`
 }
 
+// BookingActor defines model for BookingActor.
+type BookingActor string
+
 // BookingCreateRequest defines model for BookingCreateRequest.
 type BookingCreateRequest struct {
 	Comment *string `json:"comment"`
@@ -49,10 +228,16 @@ type BookingCreateRequest struct {
 	// Date Дата записи (TZ студии)
 	Date openapi_types.Date `json:"date"`
 
-	// DurationMinutes Длительность (кратно slot_minutes). Сервер проверит, что весь интервал свободен.
-	DurationMinutes int    `json:"duration_minutes"`
-	Name            string `json:"name"`
-	Phone           string `json:"phone"`
+	// DurationMinutes Длительность (кратно slot_minutes). Сервер проверит, что весь интервал свободен. Не нужна, если указан service_id.
+	DurationMinutes *int `json:"duration_minutes,omitempty"`
+
+	// HoldToken Токен удержания этого же интервала (createSlotHold)
+	HoldToken *string `json:"hold_token,omitempty"`
+	Name      string  `json:"name"`
+	Phone     string  `json:"phone"`
+
+	// ServiceId Услуга из каталога (GET /api/public/services)
+	ServiceId *openapi_types.UUID `json:"service_id,omitempty"`
 
 	// StartTime Время начала (кратно slot_minutes)
 	StartTime string `json:"start_time"`
@@ -65,26 +250,70 @@ type BookingCreateResponse struct {
 	DurationMinutes int                `json:"duration_minutes"`
 
 	// EndTime Рассчитанное окончание (не включительно)
-	EndTime   string             `json:"end_time"`
-	Id        openapi_types.UUID `json:"id"`
-	StartTime string             `json:"start_time"`
-	Status    BookingStatus      `json:"status"`
+	EndTime string             `json:"end_time"`
+	Id      openapi_types.UUID `json:"id"`
+
+	// ManageToken Секрет ссылки управления записью (getClientBooking, cancelClientBooking). Показывается только здесь — сохраните его или отправьте клиенту ссылку.
+	ManageToken string `json:"manage_token"`
+
+	// PendingExpiresAt Для status=pending — до какого момента заявка держит время без подтверждения
+	PendingExpiresAt *time.Time    `json:"pending_expires_at"`
+	StartTime        string        `json:"start_time"`
+	Status           BookingStatus `json:"status"`
 }
 
 // BookingDetail defines model for BookingDetail.
 type BookingDetail struct {
-	CancelReason    *string            `json:"cancel_reason"`
-	CancelledAt     *time.Time         `json:"cancelled_at"`
-	ClientName      string             `json:"client_name"`
+	CancelReason *string       `json:"cancel_reason"`
+	CancelSource *BookingActor `json:"cancel_source,omitempty"`
+	CancelledAt  *time.Time    `json:"cancelled_at"`
+
+	// CancelledBy Логин администратора, отменившего запись
+	CancelledBy *string `json:"cancelled_by"`
+
+	// ClientId Клиент из справочника; пусто для блоков
+	ClientId   *openapi_types.UUID `json:"client_id"`
+	ClientName string              `json:"client_name"`
+
+	// ClientPhone Для роли без доступа к персональным данным (viewer) маскируется — +7*******67.
 	ClientPhone     string             `json:"client_phone"`
 	Comment         *string            `json:"comment"`
+	CompletedAt     *time.Time         `json:"completed_at"`
+	ConfirmedAt     *time.Time         `json:"confirmed_at"`
 	CreatedAt       time.Time          `json:"created_at"`
 	Date            openapi_types.Date `json:"date"`
 	DurationMinutes int                `json:"duration_minutes"`
 	EndTime         string             `json:"end_time"`
 	Id              openapi_types.UUID `json:"id"`
-	StartTime       string             `json:"start_time"`
-	Status          BookingStatus      `json:"status"`
+	NoShowAt        *time.Time         `json:"no_show_at"`
+
+	// PendingExpiresAt Для status=pending — до какого момента заявка держит время без подтверждения
+	PendingExpiresAt *time.Time `json:"pending_expires_at"`
+
+	// PriceRub Цена услуги на момент записи, руб.
+	PriceRub *int `json:"price_rub"`
+
+	// Reschedules История переносов, от ранних к поздним
+	Reschedules *[]BookingReschedule `json:"reschedules,omitempty"`
+	ServiceId   *openapi_types.UUID  `json:"service_id"`
+
+	// ServiceName Название услуги на момент записи
+	ServiceName *string       `json:"service_name"`
+	StartTime   string        `json:"start_time"`
+	Status      BookingStatus `json:"status"`
+}
+
+// BookingReschedule defines model for BookingReschedule.
+type BookingReschedule struct {
+	CreatedAt  time.Time `json:"created_at"`
+	NewEndAt   time.Time `json:"new_end_at"`
+	NewStartAt time.Time `json:"new_start_at"`
+	OldEndAt   time.Time `json:"old_end_at"`
+	OldStartAt time.Time `json:"old_start_at"`
+
+	// RescheduledBy Логин администратора; null — перенёс клиент
+	RescheduledBy *string      `json:"rescheduled_by"`
+	Source        BookingActor `json:"source"`
 }
 
 // BookingStatus defines model for BookingStatus.
@@ -95,8 +324,10 @@ type BookingStatusFilter string
 
 // BookingSummary defines model for BookingSummary.
 type BookingSummary struct {
-	CancelledAt     *time.Time         `json:"cancelled_at"`
-	ClientName      string             `json:"client_name"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	ClientName  string     `json:"client_name"`
+
+	// ClientPhone Для роли без доступа к персональным данным (viewer) маскируется — +7*******67.
 	ClientPhone     string             `json:"client_phone"`
 	Comment         *string            `json:"comment"`
 	CreatedAt       time.Time          `json:"created_at"`
@@ -104,8 +335,26 @@ type BookingSummary struct {
 	DurationMinutes int                `json:"duration_minutes"`
 	EndTime         string             `json:"end_time"`
 	Id              openapi_types.UUID `json:"id"`
-	StartTime       string             `json:"start_time"`
-	Status          BookingStatus      `json:"status"`
+
+	// PendingExpiresAt Для status=pending — до какого момента заявка держит время без подтверждения
+	PendingExpiresAt *time.Time `json:"pending_expires_at"`
+
+	// PriceRub Цена услуги на момент записи, руб.
+	PriceRub  *int                `json:"price_rub"`
+	ServiceId *openapi_types.UUID `json:"service_id"`
+
+	// ServiceName Название услуги на момент записи
+	ServiceName *string       `json:"service_name"`
+	StartTime   string        `json:"start_time"`
+	Status      BookingStatus `json:"status"`
+}
+
+// BookingsConflictResponse defines model for BookingsConflictResponse.
+type BookingsConflictResponse struct {
+	// Bookings Активные записи, мешающие выполнить операцию
+	Bookings []BookingSummary `json:"bookings"`
+	Code     string           `json:"code"`
+	Message  string           `json:"message"`
 }
 
 // CancelBookingRequest defines model for CancelBookingRequest.
@@ -113,6 +362,100 @@ type CancelBookingRequest struct {
 	Reason *string `json:"reason"`
 }
 
+// Client defines model for Client.
+type Client struct {
+	// BookingsCount Неотменённые записи
+	BookingsCount int                `json:"bookings_count"`
+	CreatedAt     time.Time          `json:"created_at"`
+	Id            openapi_types.UUID `json:"id"`
+
+	// LastVisitAt Начало последнего состоявшегося визита
+	LastVisitAt *time.Time `json:"last_visit_at"`
+
+	// Name Имя из первой записи клиента
+	Name        string `json:"name"`
+	NoShowCount int    `json:"no_show_count"`
+	Phone       string `json:"phone"`
+
+	// TotalSpentRub Сумма цен состоявшихся визитов, руб.
+	TotalSpentRub int `json:"total_spent_rub"`
+
+	// VisitsCount Записи в состоянии completed
+	VisitsCount int `json:"visits_count"`
+}
+
+// ClientBooking defines model for ClientBooking.
+type ClientBooking struct {
+	CancelSource    *BookingActor      `json:"cancel_source,omitempty"`
+	CancelledAt     *time.Time         `json:"cancelled_at"`
+	ClientName      string             `json:"client_name"`
+	CreatedAt       time.Time          `json:"created_at"`
+	Date            openapi_types.Date `json:"date"`
+	DurationMinutes int                `json:"duration_minutes"`
+	EndTime         string             `json:"end_time"`
+	Id              openapi_types.UUID `json:"id"`
+
+	// PendingExpiresAt Для status=pending — до какого момента заявка держит время без подтверждения
+	PendingExpiresAt *time.Time    `json:"pending_expires_at"`
+	PriceRub         *int          `json:"price_rub"`
+	ServiceName      *string       `json:"service_name"`
+	StartTime        string        `json:"start_time"`
+	Status           BookingStatus `json:"status"`
+}
+
+// ClientCancelBookingRequest defines model for ClientCancelBookingRequest.
+type ClientCancelBookingRequest struct {
+	Reason *string `json:"reason"`
+
+	// Token manage_token из ответа createBooking
+	Token string `json:"token"`
+}
+
+// ClientMergeRequest defines model for ClientMergeRequest.
+type ClientMergeRequest struct {
+	DuplicateIds []openapi_types.UUID `json:"duplicate_ids"`
+}
+
+// ClientRescheduleBookingRequest defines model for ClientRescheduleBookingRequest.
+type ClientRescheduleBookingRequest struct {
+	Date      openapi_types.Date `json:"date"`
+	StartTime string             `json:"start_time"`
+
+	// Token manage_token из ответа createBooking
+	Token string `json:"token"`
+}
+
+// Closure defines model for Closure.
+type Closure struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// EndDate Последний закрытый день (включительно)
+	EndDate   openapi_types.Date `json:"end_date"`
+	Id        openapi_types.UUID `json:"id"`
+	Reason    string             `json:"reason"`
+	StartDate openapi_types.Date `json:"start_date"`
+}
+
+// ClosureCreateRequest defines model for ClosureCreateRequest.
+type ClosureCreateRequest struct {
+	// EndDate Последний закрытый день (включительно)
+	EndDate   openapi_types.Date `json:"end_date"`
+	Reason    string             `json:"reason"`
+	StartDate openapi_types.Date `json:"start_date"`
+}
+
+// DayAvailability defines model for DayAvailability.
+type DayAvailability struct {
+	Date openapi_types.Date `json:"date"`
+
+	// FreeSlots Число свободных стартовых слотов
+	FreeSlots int                   `json:"free_slots"`
+	Status    DayAvailabilityStatus `json:"status"`
+}
+
+// DayAvailabilityStatus defines model for DayAvailability.Status.
+type DayAvailabilityStatus string
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Code    string                  `json:"code"`
@@ -130,10 +473,146 @@ type FieldError struct {
 type FreeSlotsResponse struct {
 	Date openapi_types.Date `json:"date"`
 
+	// DurationMinutes Длительность, для которой подобраны слоты
+	DurationMinutes int `json:"duration_minutes"`
+
 	// FreeSlots Свободные стартовые слоты по сетке (HH:MM)
 	FreeSlots []string `json:"free_slots"`
 }
 
+// LoginLockout defines model for LoginLockout.
+type LoginLockout struct {
+	// Active Блокировка ещё действует
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Failures Неудачных попыток подряд на момент блокировки
+	Failures int                `json:"failures"`
+	Id       openapi_types.UUID `json:"id"`
+
+	// Key IP-адрес или логин
+	Key         string            `json:"key"`
+	LockedUntil time.Time         `json:"locked_until"`
+	Scope       LoginLockoutScope `json:"scope"`
+}
+
+// LoginLockoutScope defines model for LoginLockout.Scope.
+type LoginLockoutScope string
+
+// MfaCodeRequest defines model for MfaCodeRequest.
+type MfaCodeRequest struct {
+	// Code 6-значный код из приложения
+	Code *string `json:"code,omitempty"`
+
+	// RecoveryCode Одноразовый код восстановления (xxxxx-xxxxx)
+	RecoveryCode *string `json:"recovery_code,omitempty"`
+}
+
+// MfaStatusResponse defines model for MfaStatusResponse.
+type MfaStatusResponse struct {
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+	TotpEnabled       bool `json:"totp_enabled"`
+}
+
+// RecoveryCodesResponse defines model for RecoveryCodesResponse.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// RescheduleBookingRequest defines model for RescheduleBookingRequest.
+type RescheduleBookingRequest struct {
+	// Date Новая дата (TZ студии)
+	Date openapi_types.Date `json:"date"`
+
+	// StartTime Новое время начала (кратно slot_minutes)
+	StartTime string `json:"start_time"`
+}
+
+// SlotHoldRequest defines model for SlotHoldRequest.
+type SlotHoldRequest struct {
+	// Date Дата записи (TZ студии)
+	Date openapi_types.Date `json:"date"`
+
+	// DurationMinutes Длительность (кратно slot_minutes). Не нужна, если указан service_id.
+	DurationMinutes *int `json:"duration_minutes,omitempty"`
+
+	// ReplaceHoldToken Токен прежнего удержания этого клиента: оно снимается в той же транзакции, так что новое время может пересекаться со старым. При 409 прежнее удержание остаётся в силе.
+	ReplaceHoldToken *string `json:"replace_hold_token,omitempty"`
+
+	// ServiceId Услуга из каталога (GET /api/public/services)
+	ServiceId *openapi_types.UUID `json:"service_id,omitempty"`
+
+	// StartTime Время начала (кратно slot_minutes)
+	StartTime string `json:"start_time"`
+}
+
+// SlotHoldResponse defines model for SlotHoldResponse.
+type SlotHoldResponse struct {
+	Date            openapi_types.Date `json:"date"`
+	DurationMinutes int                `json:"duration_minutes"`
+	EndTime         string             `json:"end_time"`
+
+	// ExpiresAt После этого момента слот освобождается, если запись не создана
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// HoldToken Секрет удержания для createBooking; показывается только здесь.
+	HoldToken string `json:"hold_token"`
+	StartTime string `json:"start_time"`
+}
+
+// StudioService defines model for StudioService.
+type StudioService struct {
+	CreatedAt       time.Time          `json:"created_at"`
+	Description     *string            `json:"description"`
+	DurationMinutes int                `json:"duration_minutes"`
+	Id              openapi_types.UUID `json:"id"`
+	IsActive        bool               `json:"is_active"`
+	Name            string             `json:"name"`
+
+	// PriceRub Цена, руб.
+	PriceRub int `json:"price_rub"`
+
+	// RequiresApproval Записи на услугу ждут подтверждения администратором; null — как в BOOKING_APPROVAL_REQUIRED
+	RequiresApproval *bool     `json:"requires_approval"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// StudioServiceRequest defines model for StudioServiceRequest.
+type StudioServiceRequest struct {
+	Description *string `json:"description"`
+
+	// DurationMinutes Длительность (кратно slot_minutes, не больше max_session_minutes)
+	DurationMinutes int `json:"duration_minutes"`
+
+	// IsActive Неактивные услуги не показываются клиентам и недоступны для записи
+	IsActive *bool  `json:"is_active,omitempty"`
+	Name     string `json:"name"`
+
+	// PriceRub Цена, руб.
+	PriceRub int `json:"price_rub"`
+
+	// RequiresApproval true/false — переопределить BOOKING_APPROVAL_REQUIRED для этой услуги, null — как в общей настройке
+	RequiresApproval *bool `json:"requires_approval"`
+}
+
+// StudioServicesResponse defines model for StudioServicesResponse.
+type StudioServicesResponse struct {
+	Items []StudioService `json:"items"`
+}
+
+// TotpCodeRequest defines model for TotpCodeRequest.
+type TotpCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TotpEnrollmentResponse defines model for TotpEnrollmentResponse.
+type TotpEnrollmentResponse struct {
+	OtpauthUri string `json:"otpauth_uri"`
+
+	// Secret Секрет в base32
+	Secret string `json:"secret"`
+}
+
 // ValidationErrorResponse defines model for ValidationErrorResponse.
 type ValidationErrorResponse struct {
 	Code    ValidationErrorResponseCode `json:"code"`
@@ -144,6 +623,12 @@ type ValidationErrorResponse struct {
 // ValidationErrorResponseCode defines model for ValidationErrorResponse.Code.
 type ValidationErrorResponseCode string
 
+// BookingId defines model for BookingId.
+type BookingId = openapi_types.UUID
+
+// ClientId defines model for ClientId.
+type ClientId = openapi_types.UUID
+
 // IdempotencyKey defines model for IdempotencyKey.
 type IdempotencyKey = string
 
@@ -153,18 +638,51 @@ type AdminUnauthorized = ErrorResponse
 // BadRequest defines model for BadRequest.
 type BadRequest = ErrorResponse
 
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
+// IdempotencyInProgress defines model for IdempotencyInProgress.
+type IdempotencyInProgress = ErrorResponse
+
 // InternalError defines model for InternalError.
 type InternalError = ErrorResponse
 
 // NotFound defines model for NotFound.
 type NotFound = ErrorResponse
 
+// StatusConflict defines model for StatusConflict.
+type StatusConflict = ErrorResponse
+
 // TimeConflict defines model for TimeConflict.
 type TimeConflict = ErrorResponse
 
+// TooManyAttempts defines model for TooManyAttempts.
+type TooManyAttempts = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
 // ValidationError defines model for ValidationError.
 type ValidationError = ValidationErrorResponse
 
+// AdminListBlocksByDateParams defines parameters for AdminListBlocksByDate.
+type AdminListBlocksByDateParams struct {
+	// Date Дата YYYY-MM-DD (часовой пояс студии)
+	Date openapi_types.Date `form:"date" json:"date"`
+}
+
+// AdminApproveBookingParams defines parameters for AdminApproveBooking.
+type AdminApproveBookingParams struct {
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// AdminRejectBookingParams defines parameters for AdminRejectBooking.
+type AdminRejectBookingParams struct {
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // AdminListBookingsByDateParams defines parameters for AdminListBookingsByDate.
 type AdminListBookingsByDateParams struct {
 	// Date Дата YYYY-MM-DD (часовой пояс студии)
@@ -174,27 +692,165 @@ type AdminListBookingsByDateParams struct {
 
 // AdminCancelBookingParams defines parameters for AdminCancelBooking.
 type AdminCancelBookingParams struct {
-	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик).
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// AdminCompleteBookingParams defines parameters for AdminCompleteBooking.
+type AdminCompleteBookingParams struct {
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// AdminConfirmBookingParams defines parameters for AdminConfirmBooking.
+type AdminConfirmBookingParams struct {
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
 	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
 }
 
+// AdminMarkNoShowParams defines parameters for AdminMarkNoShow.
+type AdminMarkNoShowParams struct {
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// AdminRescheduleBookingParams defines parameters for AdminRescheduleBooking.
+type AdminRescheduleBookingParams struct {
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// AdminListClientsParams defines parameters for AdminListClients.
+type AdminListClientsParams struct {
+	Q      *string `form:"q,omitempty" json:"q,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int    `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// AdminMergeClientsParams defines parameters for AdminMergeClients.
+type AdminMergeClientsParams struct {
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// AdminListClosuresParams defines parameters for AdminListClosures.
+type AdminListClosuresParams struct {
+	// From Начало периода YYYY-MM-DD (включительно)
+	From openapi_types.Date `form:"from" json:"from"`
+
+	// To Конец периода YYYY-MM-DD (включительно)
+	To openapi_types.Date `form:"to" json:"to"`
+}
+
+// AdminListLoginLockoutsParams defines parameters for AdminListLoginLockouts.
+type AdminListLoginLockoutsParams struct {
+	// Limit Сколько записей вернуть (по умолчанию 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// AdminOidcCallbackParams defines parameters for AdminOidcCallback.
+type AdminOidcCallbackParams struct {
+	Code  *string `form:"code,omitempty" json:"code,omitempty"`
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+
+	// Error Ошибка от провайдера (например, access_denied)
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// GetAvailabilityParams defines parameters for GetAvailability.
+type GetAvailabilityParams struct {
+	// From Первая дата периода YYYY-MM-DD
+	From openapi_types.Date `form:"from" json:"from"`
+
+	// To Последняя дата периода YYYY-MM-DD (включительно)
+	To openapi_types.Date `form:"to" json:"to"`
+
+	// DurationMinutes Длительность сеанса (кратно slot_minutes)
+	DurationMinutes *int `form:"duration_minutes,omitempty" json:"duration_minutes,omitempty"`
+
+	// ServiceId Услуга из каталога; длительность берётся из неё
+	ServiceId *openapi_types.UUID `form:"service_id,omitempty" json:"service_id,omitempty"`
+}
+
 // CreateBookingParams defines parameters for CreateBooking.
 type CreateBookingParams struct {
-	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик).
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetClientBookingParams defines parameters for GetClientBooking.
+type GetClientBookingParams struct {
+	Token string `form:"token" json:"token"`
+}
+
+// CreateSlotHoldParams defines parameters for CreateSlotHold.
+type CreateSlotHoldParams struct {
+	// IdempotencyKey Ключ идемпотентности для безопасных повторов (ретраи/двойной клик). Ключ действует в пределах операции, а для административных операций — ещё и вызывающего (администратор или API-ключ). Повтор получает сохранённые статус и тело. Тело запроса с ключом — не больше 1 МиБ.
 	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
 }
 
 // GetFreeSlotsByDateParams defines parameters for GetFreeSlotsByDate.
 type GetFreeSlotsByDateParams struct {
-	// Date Дата YYYY-MM-DD (часовой пояс студии)
+	// Date Дата YYYY-MM-DD
 	Date openapi_types.Date `form:"date" json:"date"`
+
+	// DurationMinutes Длительность сеанса (кратно slot_minutes)
+	DurationMinutes *int `form:"duration_minutes,omitempty" json:"duration_minutes,omitempty"`
+
+	// ServiceId Услуга из каталога; длительность берётся из неё
+	ServiceId *openapi_types.UUID `form:"service_id,omitempty" json:"service_id,omitempty"`
 }
 
+// AdminCreateApiKeyJSONRequestBody defines body for AdminCreateApiKey for application/json ContentType.
+type AdminCreateApiKeyJSONRequestBody = ApiKeyCreateRequest
+
+// AdminCreateBlockJSONRequestBody defines body for AdminCreateBlock for application/json ContentType.
+type AdminCreateBlockJSONRequestBody = BlockCreateRequest
+
+// AdminRejectBookingJSONRequestBody defines body for AdminRejectBooking for application/json ContentType.
+type AdminRejectBookingJSONRequestBody = CancelBookingRequest
+
 // AdminCancelBookingJSONRequestBody defines body for AdminCancelBooking for application/json ContentType.
 type AdminCancelBookingJSONRequestBody = CancelBookingRequest
 
+// AdminRescheduleBookingJSONRequestBody defines body for AdminRescheduleBooking for application/json ContentType.
+type AdminRescheduleBookingJSONRequestBody = RescheduleBookingRequest
+
+// AdminMergeClientsJSONRequestBody defines body for AdminMergeClients for application/json ContentType.
+type AdminMergeClientsJSONRequestBody = ClientMergeRequest
+
+// AdminCreateClosureJSONRequestBody defines body for AdminCreateClosure for application/json ContentType.
+type AdminCreateClosureJSONRequestBody = ClosureCreateRequest
+
+// AdminRegenerateRecoveryCodesJSONRequestBody defines body for AdminRegenerateRecoveryCodes for application/json ContentType.
+type AdminRegenerateRecoveryCodesJSONRequestBody = TotpCodeRequest
+
+// AdminConfirmTotpJSONRequestBody defines body for AdminConfirmTotp for application/json ContentType.
+type AdminConfirmTotpJSONRequestBody = TotpCodeRequest
+
+// AdminDisableTotpJSONRequestBody defines body for AdminDisableTotp for application/json ContentType.
+type AdminDisableTotpJSONRequestBody = MfaCodeRequest
+
+// AdminCreateServiceJSONRequestBody defines body for AdminCreateService for application/json ContentType.
+type AdminCreateServiceJSONRequestBody = StudioServiceRequest
+
+// AdminUpdateServiceJSONRequestBody defines body for AdminUpdateService for application/json ContentType.
+type AdminUpdateServiceJSONRequestBody = StudioServiceRequest
+
 // AdminSessionLoginJSONRequestBody defines body for AdminSessionLogin for application/json ContentType.
 type AdminSessionLoginJSONRequestBody = AdminSessionLoginRequest
 
+// AdminSessionMfaJSONRequestBody defines body for AdminSessionMfa for application/json ContentType.
+type AdminSessionMfaJSONRequestBody = MfaCodeRequest
+
 // CreateBookingJSONRequestBody defines body for CreateBooking for application/json ContentType.
 type CreateBookingJSONRequestBody = BookingCreateRequest
+
+// CancelClientBookingJSONRequestBody defines body for CancelClientBooking for application/json ContentType.
+type CancelClientBookingJSONRequestBody = ClientCancelBookingRequest
+
+// RescheduleClientBookingJSONRequestBody defines body for RescheduleClientBooking for application/json ContentType.
+type RescheduleClientBookingJSONRequestBody = ClientRescheduleBookingRequest
+
+// CreateSlotHoldJSONRequestBody defines body for CreateSlotHold for application/json ContentType.
+type CreateSlotHoldJSONRequestBody = SlotHoldRequest
@@ -11,14 +11,25 @@ import (
 	"photannie/internal/http"
 	"photannie/internal/http/handler"
 	"photannie/internal/http/session"
+	"photannie/internal/observability/metrics"
+	"photannie/internal/oidc"
 	"photannie/internal/repository/postgres"
+	"photannie/internal/service/admin"
+	"photannie/internal/service/apikey"
 	"photannie/internal/service/booking"
+	"photannie/internal/service/catalog"
+	"photannie/internal/service/clients"
+	"photannie/internal/service/closure"
+	"photannie/internal/service/idempotency"
+	"photannie/internal/service/loginguard"
+	"photannie/internal/service/oidclogin"
 )
 
 type App struct {
 	log    *slog.Logger
 	pool   *pgxpool.Pool
 	server *httpserver.Server
+	jobs   []periodicJob
 
 	closePool func()
 	closeHTTP func() error
@@ -44,30 +55,182 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) (*App, error)
 		return nil, fmt.Errorf("postgres pool: %w", err)
 	}
 
-	repo := postgres.NewBookingRepository(pool)
+	bookingRepo := postgres.NewBookingRepository(pool)
+	closureRepo := postgres.NewClosureRepository(pool)
+	serviceRepo := postgres.NewStudioServiceRepository(pool)
 
-	svc, err := booking.New(repo, booking.Rules{
+	svc, err := booking.New(booking.Deps{
+		Bookings: bookingRepo,
+		Closures: closureRepo,
+		Services: serviceRepo,
+		Logger:   log,
+	}, booking.Rules{
 		Location:          loc,
 		BookingWindowDays: cfg.Rules.BookingWindowDays,
-		WorkStartHHMM:     cfg.Rules.WorkStartHHMM,
-		WorkEndHHMM:       cfg.Rules.WorkEndHHMM,
+		Schedule:          cfg.Rules.Schedule,
 		SlotMinutes:       cfg.Rules.SlotMinutes,
 		MaxSessionMinutes: cfg.Rules.MaxSessionMinutes,
-	}, log)
+
+		BufferBeforeMinutes: cfg.Rules.BufferBeforeMinutes,
+		BufferAfterMinutes:  cfg.Rules.BufferAfterMinutes,
+
+		MinLeadTime:       cfg.Rules.MinLeadTime,
+		SameDayCutoffHHMM: cfg.Rules.SameDayCutoffHHMM,
+
+		ApprovalRequired: cfg.Rules.ApprovalRequired,
+		ApprovalHoldTime: cfg.Rules.ApprovalHoldTime,
+
+		HoldTTL:        cfg.Rules.HoldTTL,
+		HoldRateLimit:  cfg.Rules.HoldRateLimit,
+		HoldRateWindow: cfg.Rules.HoldRateWindow,
+		MaxActiveHolds: cfg.Rules.MaxActiveHolds,
+	})
 	if err != nil {
 		pool.Close()
 		return nil, fmt.Errorf("booking service: %w", err)
 	}
 
-	sessions := session.NewMemoryStore()
+	closures, err := closure.New(closure.Deps{
+		Closures: closureRepo,
+		Location: loc,
+		Logger:   log,
+	})
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("closure service: %w", err)
+	}
+
+	catalogSvc, err := catalog.New(catalog.Deps{
+		Services:          serviceRepo,
+		SlotMinutes:       cfg.Rules.SlotMinutes,
+		MaxSessionMinutes: cfg.Rules.MaxSessionMinutes,
+		Logger:            log,
+	})
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("catalog service: %w", err)
+	}
+
+	clientsSvc, err := clients.New(clients.Deps{
+		Clients: postgres.NewClientRepository(pool),
+		Logger:  log,
+	})
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("clients service: %w", err)
+	}
+
+	idem, err := idempotency.New(postgres.NewIdempotencyRepository(pool), idempotency.Options{
+		TTL:         cfg.Idempotency.TTL,
+		LockTimeout: cfg.Idempotency.LockTimeout,
+	}, log)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("idempotency service: %w", err)
+	}
+
+	admins, err := admin.New(admin.Deps{
+		Admins: postgres.NewAdminRepository(pool),
+		Logger: log,
+	})
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("admin service: %w", err)
+	}
+	if cfg.HTTP.Admin.Password != "" {
+		created, err := admins.EnsureBootstrap(ctx, admin.DefaultUsername, cfg.HTTP.Admin.Password)
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("bootstrap admin: %w", err)
+		}
+		if created {
+			log.Info("bootstrap admin created from ADMIN_PASSWORD", "username", admin.DefaultUsername)
+		}
+	}
+
+	m := metrics.New("photannie")
+
+	apiKeys, err := apikey.New(apikey.Deps{
+		Keys:   postgres.NewAPIKeyRepository(pool),
+		Logger: log,
+	})
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("api key service: %w", err)
+	}
+
+	// nil — вход через OIDC выключен, обработчики отвечают 404.
+	var oidcLogin oidclogin.Service
+	if o := cfg.HTTP.Admin.OIDC; o.Issuer != "" {
+		provider, err := oidc.NewProvider(oidc.Config{
+			Issuer:       o.Issuer,
+			ClientID:     o.ClientID,
+			ClientSecret: o.ClientSecret,
+			RedirectURL:  o.RedirectURL,
+		})
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("oidc provider: %w", err)
+		}
+		oidcLogin, err = oidclogin.New(oidclogin.Deps{
+			Provider:      provider,
+			Admins:        postgres.NewAdminRepository(pool),
+			AllowedEmails: o.AllowedEmails,
+			Logger:        log,
+		})
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("oidc login service: %w", err)
+		}
+		log.Info("admin oidc login enabled", "issuer", o.Issuer)
+	}
+
+	loginGuard, err := loginguard.New(loginguard.Deps{
+		Attempts: postgres.NewLoginAttemptRepository(pool),
+		Metrics:  m,
+		Logger:   log,
+	}, loginguard.Policy{
+		MaxFailuresPerAccount: cfg.HTTP.Admin.Login.MaxFailuresPerAccount,
+		MaxFailuresPerIP:      cfg.HTTP.Admin.Login.MaxFailuresPerIP,
+		Window:                cfg.HTTP.Admin.Login.FailureWindow,
+		LockoutBase:           cfg.HTTP.Admin.Login.LockoutBase,
+		LockoutMax:            cfg.HTTP.Admin.Login.LockoutMax,
+	})
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("login guard: %w", err)
+	}
+
+	sessionOpts := session.Options{
+		TTL:         cfg.HTTP.Admin.SessionTTL,
+		IdleTimeout: cfg.HTTP.Admin.SessionIdleTimeout,
+		MFATimeout:  cfg.HTTP.Admin.SessionMFATimeout,
+	}
+	var sessions session.Store
+	switch cfg.HTTP.Admin.SessionStore {
+	case "postgres":
+		sessions = session.NewPostgresStore(postgres.NewAdminSessionRepository(pool), sessionOpts)
+	default:
+		sessions = session.NewMemoryStore(postgres.NewAdminRepository(pool), sessionOpts)
+	}
+	log.Info("admin session store", "store", cfg.HTTP.Admin.SessionStore)
 
 	h := handler.New(handler.Deps{
-		Booking:       svc,
-		AdminPassword: cfg.HTTP.Admin.Password,
-		CookieName:    cfg.HTTP.Admin.SessionCookieName,
-		SecureCookie:  cfg.HTTP.Admin.SecureCookie,
-		Sessions:      sessions,
-		Logger:        log,
+		Booking:      svc,
+		Idempotency:  idem,
+		Closures:     closures,
+		Catalog:      catalogSvc,
+		Clients:      clientsSvc,
+		Admins:       admins,
+		LoginGuard:   loginGuard,
+		APIKeys:      apiKeys,
+		CookieName:   cfg.HTTP.Admin.SessionCookieName,
+		SecureCookie: cfg.HTTP.Admin.SecureCookie,
+		Sessions:     sessions,
+		Logger:       log,
+
+		OIDC:             oidcLogin,
+		OIDCPostLoginURL: cfg.HTTP.Admin.OIDC.PostLoginURL,
 	})
 
 	srv := httpserver.New(httpserver.Config{
@@ -80,20 +243,71 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) (*App, error)
 		ShutdownTimeout: cfg.HTTP.ShutdownTimeout,
 
 		AllowedOrigins:    cfg.HTTP.CORS.AllowedOrigins,
+		TrustedProxies:    cfg.HTTP.TrustedProxies,
 		SessionCookieName: cfg.HTTP.Admin.SessionCookieName,
 		SecureCookie:      cfg.HTTP.Admin.SecureCookie,
+		APIKeys:           apiKeys,
+
+		Metrics: m,
 	}, h, sessions, log)
 
+	jobs := []periodicJob{
+		{
+			name:     "idempotency_purge",
+			interval: cfg.Idempotency.CleanupInterval,
+			run: func(ctx context.Context) error {
+				_, err := idem.PurgeExpired(ctx)
+				return err
+			},
+		},
+		{
+			name:     "pending_bookings_expire",
+			interval: cfg.Rules.ApprovalExpiryInterval,
+			run: func(ctx context.Context) error {
+				_, err := svc.ExpirePendingBookings(ctx)
+				return err
+			},
+		},
+		{
+			name:     "slot_holds_release",
+			interval: cfg.Rules.HoldReleaseInterval,
+			run: func(ctx context.Context) error {
+				_, err := svc.ReleaseExpiredHolds(ctx)
+				return err
+			},
+		},
+		{
+			name:     "admin_session_purge",
+			interval: cfg.HTTP.Admin.SessionCleanupInterval,
+			run: func(ctx context.Context) error {
+				_, err := sessions.DeleteExpired(ctx)
+				return err
+			},
+		},
+		{
+			name:     "login_attempts_purge",
+			interval: cfg.HTTP.Admin.Login.CleanupInterval,
+			run: func(ctx context.Context) error {
+				_, err := loginGuard.PurgeStale(ctx)
+				return err
+			},
+		},
+	}
+
 	return &App{
 		log:       log,
 		pool:      pool,
 		server:    srv,
+		jobs:      jobs,
 		closePool: pool.Close,
 		closeHTTP: srv.Close,
 	}, nil
 }
 
 func (a *App) Run(ctx context.Context) error {
+	for _, j := range a.jobs {
+		go runPeriodic(ctx, a.log, j)
+	}
 	return a.server.ListenAndServe(ctx)
 }
 
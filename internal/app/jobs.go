@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type periodicJob struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+func runPeriodic(ctx context.Context, log *slog.Logger, j periodicJob) {
+	t := time.NewTicker(j.interval)
+	defer t.Stop()
+
+	log.Info("background job started", "job", j.name, "interval", j.interval.String())
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("background job stopped", "job", j.name)
+			return
+		case <-t.C:
+			if err := j.run(ctx); err != nil {
+				log.Error("background job failed", "job", j.name, "err", err)
+			}
+		}
+	}
+}
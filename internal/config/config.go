@@ -2,14 +2,19 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
+	"slices"
 	"time"
+
+	"photannie/internal/service/booking"
 )
 
 type Config struct {
-	App      App
-	HTTP     HTTP
-	Postgres Postgres
-	Rules    BookingRules
+	App         App
+	HTTP        HTTP
+	Postgres    Postgres
+	Rules       BookingRules
+	Idempotency Idempotency
 }
 
 type App struct {
@@ -33,6 +38,10 @@ type HTTP struct {
 
 	CORS CORS
 
+	// TrustedProxies из TRUSTED_PROXIES: только от этих адресов принимаются X-Forwarded-For и X-Real-IP.
+	// Пусто — адрес клиента берётся из соединения.
+	TrustedProxies []netip.Prefix
+
 	Admin AdminAuth
 }
 
@@ -41,9 +50,38 @@ type CORS struct {
 }
 
 type AdminAuth struct {
-	Password          string
+	Password          string // пароль первого администратора admin; используется, только пока таблица admins пуста
 	SessionCookieName string
 	SecureCookie      bool
+
+	SessionStore           string        // memory | postgres
+	SessionTTL             time.Duration // 12h — абсолютный срок сессии
+	SessionIdleTimeout     time.Duration // 2h — простой, после которого сессия истекает
+	SessionCleanupInterval time.Duration // 10m
+	SessionMFATimeout      time.Duration // 5m — на ввод второго фактора после пароля
+
+	Login LoginThrottle
+	OIDC  OIDC
+}
+
+// OIDC — вход через провайдера удостоверений; пустой Issuer отключает его.
+type OIDC struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // https://studio.example/api/admin/oidc/callback
+
+	AllowedEmails []string // "email" или "email:логин"
+	PostLoginURL  string   // куда вернуть браузер после входа, по умолчанию /admin
+}
+
+type LoginThrottle struct {
+	MaxFailuresPerAccount int           // 5
+	MaxFailuresPerIP      int           // 20
+	FailureWindow         time.Duration // 15m
+	LockoutBase           time.Duration // 30s
+	LockoutMax            time.Duration // 15m
+	CleanupInterval       time.Duration // 10m — как часто удаляются счётчики без неудач дольше FailureWindow
 }
 
 type Postgres struct {
@@ -53,13 +91,37 @@ type Postgres struct {
 	MinConns int32
 }
 
+type Idempotency struct {
+	TTL             time.Duration // 24h
+	LockTimeout     time.Duration // 1m
+	CleanupInterval time.Duration // 1h
+}
+
 type BookingRules struct {
 	Timezone          string // "Europe/Moscow"
 	BookingWindowDays int    // 90
-	WorkStartHHMM     string // "09:00"
-	WorkEndHHMM       string // "18:00"
-	SlotMinutes       int    // 30
+	SlotMinutes       int    // одно из booking.AllowedSlotMinutes
 	MaxSessionMinutes int    // напр. 180
+
+	BufferBeforeMinutes int // 0
+	BufferAfterMinutes  int // 0
+
+	MinLeadTime       time.Duration // 0 — достаточно, чтобы слот не начался
+	SameDayCutoffHHMM string        // "" — без ограничения
+
+	ApprovalRequired       bool          // false — записи сразу подтверждены; услуга может переопределить
+	ApprovalHoldTime       time.Duration // 24h — сколько заявка держит время без подтверждения
+	ApprovalExpiryInterval time.Duration // 1m — как часто отменяются истёкшие заявки
+
+	HoldTTL             time.Duration // 5m — сколько слот держится за клиентом, пока он заполняет форму
+	HoldReleaseInterval time.Duration // 1m — как часто удаляются истёкшие удержания
+	HoldRateLimit       int           // 10 — удержаний с одного IP за HoldRateWindow
+	HoldRateWindow      time.Duration // 10m
+	MaxActiveHolds      int           // 2 — действующих удержаний с одного IP одновременно
+
+	// Schedule из WORK_SCHEDULE; если не задан — пн–пт с WORK_START до WORK_END.
+	// Время, пересечения интервалов и выравнивание по сетке проверяет booking.New.
+	Schedule booking.WeeklySchedule
 }
 
 func (c Config) Validate() error {
@@ -86,12 +148,44 @@ func (c Config) Validate() error {
 		return fmt.Errorf("HTTP_*_TIMEOUT must be > 0")
 	}
 
-	if c.HTTP.Admin.Password == "" {
-		return fmt.Errorf("ADMIN_PASSWORD is required")
-	}
 	if c.HTTP.Admin.SessionCookieName == "" {
 		return fmt.Errorf("SESSION_COOKIE_NAME is required")
 	}
+	if c.HTTP.Admin.SessionStore != "memory" && c.HTTP.Admin.SessionStore != "postgres" {
+		return fmt.Errorf("SESSION_STORE must be memory or postgres")
+	}
+	if c.HTTP.Admin.SessionTTL <= 0 {
+		return fmt.Errorf("SESSION_TTL must be > 0")
+	}
+	if c.HTTP.Admin.SessionIdleTimeout <= 0 {
+		return fmt.Errorf("SESSION_IDLE_TIMEOUT must be > 0")
+	}
+	if c.HTTP.Admin.SessionCleanupInterval <= 0 {
+		return fmt.Errorf("SESSION_CLEANUP_INTERVAL must be > 0")
+	}
+	if c.HTTP.Admin.SessionMFATimeout <= 0 {
+		return fmt.Errorf("SESSION_MFA_TIMEOUT must be > 0")
+	}
+	if c.HTTP.Admin.Login.MaxFailuresPerAccount <= 0 || c.HTTP.Admin.Login.MaxFailuresPerIP <= 0 {
+		return fmt.Errorf("LOGIN_MAX_FAILURES / LOGIN_MAX_FAILURES_PER_IP must be > 0")
+	}
+	if c.HTTP.Admin.Login.FailureWindow <= 0 {
+		return fmt.Errorf("LOGIN_FAILURE_WINDOW must be > 0")
+	}
+	if c.HTTP.Admin.Login.LockoutBase <= 0 || c.HTTP.Admin.Login.LockoutMax < c.HTTP.Admin.Login.LockoutBase {
+		return fmt.Errorf("LOGIN_LOCKOUT_BASE must be > 0 and <= LOGIN_LOCKOUT_MAX")
+	}
+	if c.HTTP.Admin.Login.CleanupInterval <= 0 {
+		return fmt.Errorf("LOGIN_ATTEMPTS_CLEANUP_INTERVAL must be > 0")
+	}
+	if o := c.HTTP.Admin.OIDC; o.Issuer != "" {
+		if o.ClientID == "" || o.RedirectURL == "" {
+			return fmt.Errorf("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required when OIDC_ISSUER is set")
+		}
+		if len(o.AllowedEmails) == 0 {
+			return fmt.Errorf("OIDC_ALLOWED_EMAILS is required when OIDC_ISSUER is set")
+		}
+	}
 
 	if c.Postgres.ConnString == "" {
 		return fmt.Errorf("POSTGRES_DSN is required")
@@ -103,11 +197,8 @@ func (c Config) Validate() error {
 	if c.Rules.BookingWindowDays <= 0 {
 		return fmt.Errorf("BOOKING_WINDOW_DAYS must be > 0")
 	}
-	if c.Rules.WorkStartHHMM == "" || c.Rules.WorkEndHHMM == "" {
-		return fmt.Errorf("WORK_START / WORK_END required (HH:MM)")
-	}
-	if c.Rules.SlotMinutes <= 0 {
-		return fmt.Errorf("SLOT_MINUTES must be > 0")
+	if !slices.Contains(booking.AllowedSlotMinutes, c.Rules.SlotMinutes) {
+		return fmt.Errorf("SLOT_MINUTES must be one of %v", booking.AllowedSlotMinutes)
 	}
 	if c.Rules.MaxSessionMinutes <= 0 {
 		return fmt.Errorf("MAX_SESSION_MINUTES must be > 0")
@@ -115,6 +206,43 @@ func (c Config) Validate() error {
 	if c.Rules.MaxSessionMinutes%c.Rules.SlotMinutes != 0 {
 		return fmt.Errorf("MAX_SESSION_MINUTES must be multiple of SLOT_MINUTES")
 	}
+	if c.Rules.BufferBeforeMinutes < 0 || c.Rules.BufferAfterMinutes < 0 {
+		return fmt.Errorf("BUFFER_BEFORE_MINUTES / BUFFER_AFTER_MINUTES must be >= 0")
+	}
+	if c.Rules.MinLeadTime < 0 {
+		return fmt.Errorf("MIN_LEAD_TIME must be >= 0")
+	}
+	if c.Rules.ApprovalHoldTime <= 0 {
+		return fmt.Errorf("BOOKING_APPROVAL_HOLD must be > 0")
+	}
+	if c.Rules.ApprovalExpiryInterval <= 0 {
+		return fmt.Errorf("BOOKING_APPROVAL_EXPIRY_INTERVAL must be > 0")
+	}
+	if c.Rules.HoldTTL <= 0 {
+		return fmt.Errorf("SLOT_HOLD_TTL must be > 0")
+	}
+	if c.Rules.HoldReleaseInterval <= 0 {
+		return fmt.Errorf("SLOT_HOLD_RELEASE_INTERVAL must be > 0")
+	}
+	if c.Rules.HoldRateLimit <= 0 {
+		return fmt.Errorf("SLOT_HOLD_RATE_LIMIT must be > 0")
+	}
+	if c.Rules.HoldRateWindow <= 0 {
+		return fmt.Errorf("SLOT_HOLD_RATE_WINDOW must be > 0")
+	}
+	if c.Rules.MaxActiveHolds <= 0 {
+		return fmt.Errorf("SLOT_HOLD_MAX_ACTIVE must be > 0")
+	}
+
+	if c.Idempotency.TTL <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_TTL must be > 0")
+	}
+	if c.Idempotency.LockTimeout <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_LOCK_TIMEOUT must be > 0")
+	}
+	if c.Idempotency.CleanupInterval <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_CLEANUP_INTERVAL must be > 0")
+	}
 
 	return nil
 }
@@ -2,10 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	"photannie/internal/service/booking"
 )
 
 func Load() (Config, error) {
@@ -35,6 +38,29 @@ func Load() (Config, error) {
 				Password:          mustEnv("ADMIN_PASSWORD"),
 				SessionCookieName: getEnv("SESSION_COOKIE_NAME", "photannie_session"),
 				SecureCookie:      getEnvBool("SECURE_COOKIE", false),
+
+				SessionStore:           getEnv("SESSION_STORE", "memory"),
+				SessionTTL:             getEnvDuration("SESSION_TTL", 12*time.Hour),
+				SessionIdleTimeout:     getEnvDuration("SESSION_IDLE_TIMEOUT", 2*time.Hour),
+				SessionCleanupInterval: getEnvDuration("SESSION_CLEANUP_INTERVAL", 10*time.Minute),
+				SessionMFATimeout:      getEnvDuration("SESSION_MFA_TIMEOUT", 5*time.Minute),
+
+				Login: LoginThrottle{
+					MaxFailuresPerAccount: getEnvInt("LOGIN_MAX_FAILURES", 5),
+					MaxFailuresPerIP:      getEnvInt("LOGIN_MAX_FAILURES_PER_IP", 20),
+					FailureWindow:         getEnvDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+					LockoutBase:           getEnvDuration("LOGIN_LOCKOUT_BASE", 30*time.Second),
+					LockoutMax:            getEnvDuration("LOGIN_LOCKOUT_MAX", 15*time.Minute),
+					CleanupInterval:       getEnvDuration("LOGIN_ATTEMPTS_CLEANUP_INTERVAL", 10*time.Minute),
+				},
+				OIDC: OIDC{
+					Issuer:        getEnv("OIDC_ISSUER", ""),
+					ClientID:      getEnv("OIDC_CLIENT_ID", ""),
+					ClientSecret:  getEnv("OIDC_CLIENT_SECRET", ""),
+					RedirectURL:   getEnv("OIDC_REDIRECT_URL", ""),
+					AllowedEmails: getEnvCSV("OIDC_ALLOWED_EMAILS", nil),
+					PostLoginURL:  getEnv("OIDC_POST_LOGIN_URL", "/admin"),
+				},
 			},
 		},
 		Postgres: Postgres{
@@ -45,12 +71,43 @@ func Load() (Config, error) {
 		Rules: BookingRules{
 			Timezone:          getEnv("STUDIO_TZ", "Europe/Moscow"),
 			BookingWindowDays: getEnvInt("BOOKING_WINDOW_DAYS", 90),
-			WorkStartHHMM:     getEnv("WORK_START", "09:00"),
-			WorkEndHHMM:       getEnv("WORK_END", "18:00"),
 			SlotMinutes:       getEnvInt("SLOT_MINUTES", 30),
 			MaxSessionMinutes: getEnvInt("MAX_SESSION_MINUTES", 180),
+
+			BufferBeforeMinutes: getEnvInt("BUFFER_BEFORE_MINUTES", 0),
+			BufferAfterMinutes:  getEnvInt("BUFFER_AFTER_MINUTES", 0),
+
+			MinLeadTime:       getEnvDuration("MIN_LEAD_TIME", 0),
+			SameDayCutoffHHMM: getEnv("SAME_DAY_CUTOFF", ""),
+
+			ApprovalRequired:       getEnvBool("BOOKING_APPROVAL_REQUIRED", false),
+			ApprovalHoldTime:       getEnvDuration("BOOKING_APPROVAL_HOLD", 24*time.Hour),
+			ApprovalExpiryInterval: getEnvDuration("BOOKING_APPROVAL_EXPIRY_INTERVAL", time.Minute),
+
+			HoldTTL:             getEnvDuration("SLOT_HOLD_TTL", 5*time.Minute),
+			HoldReleaseInterval: getEnvDuration("SLOT_HOLD_RELEASE_INTERVAL", time.Minute),
+			HoldRateLimit:       getEnvInt("SLOT_HOLD_RATE_LIMIT", 10),
+			HoldRateWindow:      getEnvDuration("SLOT_HOLD_RATE_WINDOW", 10*time.Minute),
+			MaxActiveHolds:      getEnvInt("SLOT_HOLD_MAX_ACTIVE", 2),
 		},
+		Idempotency: Idempotency{
+			TTL:             getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+			LockTimeout:     getEnvDuration("IDEMPOTENCY_LOCK_TIMEOUT", time.Minute),
+			CleanupInterval: getEnvDuration("IDEMPOTENCY_CLEANUP_INTERVAL", time.Hour),
+		},
+	}
+
+	schedule, err := getEnvSchedule("WORK_SCHEDULE", "WORK_START", "WORK_END")
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Rules.Schedule = schedule
+
+	proxies, err := getEnvPrefixes("TRUSTED_PROXIES")
+	if err != nil {
+		return Config{}, err
 	}
+	cfg.HTTP.TrustedProxies = proxies
 
 	if err := cfg.Validate(); err != nil {
 		return Config{}, err
@@ -113,6 +170,39 @@ func getEnvDuration(key string, def time.Duration) time.Duration {
 	return d
 }
 
+// getEnvSchedule читает недельное расписание; startKey/endKey читаются, только если оно не задано:
+// тогда рабочие дни — пн–пт одним интервалом.
+func getEnvSchedule(key, startKey, endKey string) (booking.WeeklySchedule, error) {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return defaultWeeklySchedule(getEnv(startKey, "09:00"), getEnv(endKey, "18:00")), nil
+	}
+	s, err := ParseWeeklySchedule(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	return s, nil
+}
+
+// getEnvPrefixes читает список подсетей и адресов через запятую; адрес считается подсетью из одного адреса.
+func getEnvPrefixes(key string) ([]netip.Prefix, error) {
+	items := getEnvCSV(key, nil)
+	out := make([]netip.Prefix, 0, len(items))
+	for _, it := range items {
+		if p, err := netip.ParsePrefix(it); err == nil {
+			out = append(out, p.Masked())
+			continue
+		}
+		a, err := netip.ParseAddr(it)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is neither an IP nor a CIDR", key, it)
+		}
+		a = a.Unmap()
+		out = append(out, netip.PrefixFrom(a, a.BitLen()))
+	}
+	return out, nil
+}
+
 func getEnvCSV(key string, def []string) []string {
 	v := strings.TrimSpace(os.Getenv(key))
 	if v == "" {
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"photannie/internal/service/booking"
+)
+
+var weekdayNames = []struct {
+	name string
+	day  time.Weekday
+}{
+	{"mon", time.Monday},
+	{"tue", time.Tuesday},
+	{"wed", time.Wednesday},
+	{"thu", time.Thursday},
+	{"fri", time.Friday},
+	{"sat", time.Saturday},
+	{"sun", time.Sunday},
+}
+
+func defaultWeeklySchedule(startHHMM, endHHMM string) booking.WeeklySchedule {
+	out := make(booking.WeeklySchedule, 5)
+	for _, d := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday} {
+		out[d] = []booking.WorkInterval{{StartHHMM: startHHMM, EndHHMM: endHHMM}}
+	}
+	return out
+}
+
+// ParseWeeklySchedule разбирает строку вида
+// "mon-fri=09:00-13:00,14:00-18:00;sat=10:00-20:00;sun=closed".
+// Дни, не упомянутые в строке, считаются выходными. Здесь разбирается только запись;
+// время, пересечения и сетку проверяет booking.New.
+func ParseWeeklySchedule(s string) (booking.WeeklySchedule, error) {
+	out := make(booking.WeeklySchedule, 7)
+	seen := make(map[time.Weekday]bool, 7)
+
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		daysStr, intervalsStr, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("bad day spec %q: expected <days>=<intervals>", part)
+		}
+
+		days, err := parseWeekdayRange(strings.TrimSpace(daysStr))
+		if err != nil {
+			return nil, err
+		}
+
+		intervals, err := parseWorkIntervals(strings.TrimSpace(intervalsStr))
+		if err != nil {
+			return nil, fmt.Errorf("bad intervals for %q: %w", daysStr, err)
+		}
+
+		for _, d := range days {
+			if seen[d] {
+				return nil, fmt.Errorf("weekday %s is specified more than once", d)
+			}
+			seen[d] = true
+			if len(intervals) > 0 {
+				out[d] = intervals
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func parseWeekdayRange(s string) ([]time.Weekday, error) {
+	from, to, isRange := strings.Cut(strings.ToLower(s), "-")
+	fromIdx := weekdayIndex(from)
+	if fromIdx < 0 {
+		return nil, fmt.Errorf("unknown weekday %q (use mon..sun)", from)
+	}
+	toIdx := fromIdx
+	if isRange {
+		toIdx = weekdayIndex(to)
+		if toIdx < 0 {
+			return nil, fmt.Errorf("unknown weekday %q (use mon..sun)", to)
+		}
+		if toIdx < fromIdx {
+			return nil, fmt.Errorf("bad weekday range %q: week starts on mon", s)
+		}
+	}
+
+	out := make([]time.Weekday, 0, toIdx-fromIdx+1)
+	for i := fromIdx; i <= toIdx; i++ {
+		out = append(out, weekdayNames[i].day)
+	}
+	return out, nil
+}
+
+func weekdayIndex(name string) int {
+	for i, w := range weekdayNames {
+		if w.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseWorkIntervals(s string) ([]booking.WorkInterval, error) {
+	if strings.EqualFold(s, "closed") {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	out := make([]booking.WorkInterval, 0, len(parts))
+	for _, p := range parts {
+		startStr, endStr, ok := strings.Cut(strings.TrimSpace(p), "-")
+		if !ok {
+			return nil, fmt.Errorf("bad interval %q: expected HH:MM-HH:MM", p)
+		}
+		out = append(out, booking.WorkInterval{StartHHMM: strings.TrimSpace(startStr), EndHHMM: strings.TrimSpace(endStr)})
+	}
+	return out, nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"photannie/internal/service/booking"
+)
+
+func iv(start, end string) booking.WorkInterval {
+	return booking.WorkInterval{StartHHMM: start, EndHHMM: end}
+}
+
+func TestParseWeeklySchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want booking.WeeklySchedule
+	}{
+		{
+			name: "range with break",
+			in:   "mon-wed=09:00-13:00,14:00-18:00",
+			want: booking.WeeklySchedule{
+				time.Monday:    {iv("09:00", "13:00"), iv("14:00", "18:00")},
+				time.Tuesday:   {iv("09:00", "13:00"), iv("14:00", "18:00")},
+				time.Wednesday: {iv("09:00", "13:00"), iv("14:00", "18:00")},
+			},
+		},
+		{
+			name: "end of day",
+			in:   "sat=18:00-24:00",
+			want: booking.WeeklySchedule{time.Saturday: {iv("18:00", "24:00")}},
+		},
+		{
+			name: "closed and unmentioned days are empty",
+			in:   "mon=09:00-18:00;sun=closed",
+			want: booking.WeeklySchedule{time.Monday: {iv("09:00", "18:00")}},
+		},
+		{
+			name: "spaces, case and empty parts",
+			in:   " MON = 09:00 - 18:00 ;; tue=CLOSED; ",
+			want: booking.WeeklySchedule{time.Monday: {iv("09:00", "18:00")}},
+		},
+		{
+			name: "empty string",
+			in:   "",
+			want: booking.WeeklySchedule{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWeeklySchedule(tt.in)
+			if err != nil {
+				t.Fatalf("ParseWeeklySchedule(%q): %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWeeklySchedule(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWeeklyScheduleErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr string
+	}{
+		{"no equals sign", "mon 09:00-18:00", "expected <days>=<intervals>"},
+		{"unknown weekday", "mo=09:00-18:00", "unknown weekday"},
+		{"unknown range end", "mon-xyz=09:00-18:00", "unknown weekday"},
+		{"reversed range", "fri-mon=09:00-18:00", "week starts on mon"},
+		{"day twice", "mon-fri=09:00-18:00;wed=10:00-12:00", "more than once"},
+		{"empty day", "mon=", "expected HH:MM-HH:MM"},
+		{"no dash", "mon=09:00", "expected HH:MM-HH:MM"},
+		{"trailing comma", "mon=09:00-13:00,", "expected HH:MM-HH:MM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWeeklySchedule(tt.in)
+			if err == nil {
+				t.Fatalf("ParseWeeklySchedule(%q): want error", tt.in)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseWeeklySchedule(%q) error = %q, want it to contain %q", tt.in, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetEnvSchedule(t *testing.T) {
+	weekdays := func(start, end string) booking.WeeklySchedule {
+		ws := booking.WeeklySchedule{}
+		for d := time.Monday; d <= time.Friday; d++ {
+			ws[d] = []booking.WorkInterval{iv(start, end)}
+		}
+		return ws
+	}
+
+	tests := []struct {
+		name     string
+		schedule string
+		start    string
+		end      string
+		want     booking.WeeklySchedule
+	}{
+		{"schedule wins over work start/end", "sat=10:00-14:00", "09:15", "", booking.WeeklySchedule{time.Saturday: {iv("10:00", "14:00")}}},
+		{"fallback to work start/end", "", "10:00", "19:00", weekdays("10:00", "19:00")},
+		{"fallback defaults", "", "", "", weekdays("09:00", "18:00")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WORK_SCHEDULE", tt.schedule)
+			t.Setenv("WORK_START", tt.start)
+			t.Setenv("WORK_END", tt.end)
+
+			got, err := getEnvSchedule("WORK_SCHEDULE", "WORK_START", "WORK_END")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getEnvSchedule = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AdminRole string
+
+const (
+	AdminRoleOwner   AdminRole = "owner"   // всё, включая сессии и блокировки входа других администраторов
+	AdminRoleManager AdminRole = "manager" // расписание целиком, без управления администраторами
+	AdminRoleViewer  AdminRole = "viewer"  // только просмотр расписания, телефоны клиентов скрыты
+)
+
+func (r AdminRole) Valid() bool {
+	switch r {
+	case AdminRoleOwner, AdminRoleManager, AdminRoleViewer:
+		return true
+	}
+	return false
+}
+
+type Permission string
+
+func (p Permission) Valid() bool {
+	switch p {
+	case PermScheduleRead, PermScheduleWrite, PermClientPII, PermAdminsManage:
+		return true
+	}
+	return false
+}
+
+const (
+	PermScheduleRead  Permission = "schedule:read"
+	PermScheduleWrite Permission = "schedule:write"
+	PermClientPII     Permission = "clients:pii"
+	PermAdminsManage  Permission = "admins:manage"
+)
+
+var rolePermissions = map[AdminRole][]Permission{
+	AdminRoleOwner:   {PermScheduleRead, PermScheduleWrite, PermClientPII, PermAdminsManage},
+	AdminRoleManager: {PermScheduleRead, PermScheduleWrite, PermClientPII},
+	AdminRoleViewer:  {PermScheduleRead},
+}
+
+func (r AdminRole) Can(p Permission) bool {
+	for _, x := range rolePermissions[r] {
+		if x == p {
+			return true
+		}
+	}
+	return false
+}
+
+type Admin struct {
+	ID       uuid.UUID
+	Username string
+	Role     AdminRole
+
+	// APIKeyID — запрос пришёл по API-ключу; права дополнительно ограничены Scopes ключа.
+	APIKeyID *uuid.UUID
+	Scopes   []Permission
+
+	PasswordHash string
+
+	// TOTPSecret без TOTPEnabledAt — подключение TOTP начато, но не подтверждено кодом.
+	TOTPSecret    *string
+	TOTPEnabledAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Can — право с учётом роли и, для запросов по API-ключу, его scopes.
+func (a Admin) Can(p Permission) bool {
+	if !a.Role.Can(p) {
+		return false
+	}
+	if a.APIKeyID == nil {
+		return true
+	}
+	for _, x := range a.Scopes {
+		if x == p {
+			return true
+		}
+	}
+	return false
+}
+
+func (a Admin) Permissions() []Permission {
+	out := make([]Permission, 0, len(rolePermissions[a.Role]))
+	for _, p := range rolePermissions[a.Role] {
+		if a.Can(p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (a Admin) TOTPEnabled() bool { return a.TOTPEnabledAt != nil && a.TOTPSecret != nil }
+
+type adminCtxKey struct{}
+
+// ContextWithAdmin кладёт в контекст администратора, от имени которого выполняется запрос.
+func ContextWithAdmin(ctx context.Context, a Admin) context.Context {
+	return context.WithValue(ctx, adminCtxKey{}, a)
+}
+
+func AdminFromContext(ctx context.Context) (Admin, bool) {
+	a, ok := ctx.Value(adminCtxKey{}).(Admin)
+	return a, ok
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminSession — сессия администратора.
+// Token — значение cookie, наружу (в списки) не отдаётся; ID — публичный идентификатор для отзыва.
+type AdminSession struct {
+	ID    uuid.UUID
+	Token string
+
+	AdminID       uuid.UUID
+	AdminUsername string
+	AdminRole     AdminRole
+
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time // абсолютный срок; простой ограничивается отдельно (idle timeout)
+
+	IP        string
+	UserAgent string
+
+	// MFAPending — пароль проверен, второй фактор ещё нет; такая сессия годится только для шага MFA.
+	MFAPending bool
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey — ключ для скриптов и интеграций; сам ключ показывается один раз при создании,
+// хранится только его хэш. Prefix — начало ключа, чтобы отличать ключи в списке.
+type APIKey struct {
+	ID     uuid.UUID
+	Name   string
+	Prefix string
+	Scopes []Permission
+
+	// Ключ действует от имени создавшего его администратора и не шире его роли.
+	CreatedBy         uuid.UUID
+	CreatedByUsername string
+	CreatedByRole     AdminRole
+
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time // nil — бессрочный
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func (k APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
@@ -1,33 +1,151 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// BookingStatus — состояние клиентской записи: pending → confirmed → completed | no_show;
+// из любого незавершённого состояния запись можно отменить.
 type BookingStatus string
 
 const (
-	BookingStatusActive    BookingStatus = "active"
+	BookingStatusPending   BookingStatus = "pending" // ждёт подтверждения администратором
+	BookingStatusConfirmed BookingStatus = "confirmed"
+	BookingStatusCompleted BookingStatus = "completed"
+	BookingStatusNoShow    BookingStatus = "no_show"
 	BookingStatusCancelled BookingStatus = "cancelled"
 )
 
+// bookingTransitions — допустимые переходы; completed, no_show и cancelled — конечные состояния.
+var bookingTransitions = map[BookingStatus][]BookingStatus{
+	BookingStatusPending:   {BookingStatusConfirmed, BookingStatusCancelled},
+	BookingStatusConfirmed: {BookingStatusCompleted, BookingStatusNoShow, BookingStatusCancelled},
+}
+
+func (s BookingStatus) IsFinal() bool { return len(bookingTransitions[s]) == 0 }
+
+func (s BookingStatus) CanTransitionTo(next BookingStatus) bool {
+	for _, to := range bookingTransitions[s] {
+		if to == next {
+			return true
+		}
+	}
+	return false
+}
+
+var ErrInvalidTransition = errors.New("invalid booking status transition")
+
+// BookingTransitionError — переход между состояниями записи не предусмотрен.
+type BookingTransitionError struct {
+	From BookingStatus
+	To   BookingStatus
+}
+
+func (e BookingTransitionError) Error() string {
+	return fmt.Sprintf("booking status transition %s -> %s is not allowed", e.From, e.To)
+}
+
+func (e BookingTransitionError) Unwrap() error { return ErrInvalidTransition }
+
+// BookingActor — кто изменил запись: отменил или перенёс.
+type BookingActor string
+
+const (
+	BookingActorAdmin  BookingActor = "admin"
+	BookingActorClient BookingActor = "client" // по ссылке управления записью
+	BookingActorSystem BookingActor = "system" // заявка не подтверждена в срок
+)
+
+// BookingKind — тип записи: клиентская запись, блокировка времени админом
+// или временное удержание слота клиентом на время оформления.
+type BookingKind string
+
+const (
+	BookingKindClient BookingKind = "client"
+	BookingKindBlock  BookingKind = "block"
+	BookingKindHold   BookingKind = "hold"
+)
+
 type Booking struct {
-	ID uuid.UUID
+	ID   uuid.UUID
+	Kind BookingKind
 
 	StartAt time.Time
 	EndAt   time.Time
 
+	// Интервал вместе с буферами до/после; именно он не должен пересекаться с другими активными записями.
+	OccupiedStartAt time.Time
+	OccupiedEndAt   time.Time
+
+	// Для блокировок имя и телефон пустые, причина хранится в Comment.
 	ClientName  string
 	ClientPhone string
 	Comment     *string
 
+	// ClientID — клиент из справочника; есть у каждой клиентской записи.
+	ClientID *uuid.UUID
+
+	// Услуга из каталога; название и цена зафиксированы на момент записи.
+	ServiceID   *uuid.UUID
+	ServiceName *string
+	PriceRub    *int
+
 	Status BookingStatus
 
+	// До этого момента заявка в состоянии pending держит время; затем отменяется автоматически.
+	PendingExpiresAt *time.Time
+
+	// Момент перехода в соответствующее состояние.
 	CreatedAt    time.Time
+	ConfirmedAt  *time.Time
+	CompletedAt  *time.Time
+	NoShowAt     *time.Time
 	CancelledAt  *time.Time
 	CancelReason *string
+	CancelledBy  *string // логин администратора
+	CancelSource *BookingActor
 }
 
 func (b Booking) IsCancelled() bool { return b.Status == BookingStatusCancelled }
+
+// OccupiesTime — запись занимает своё время в расписании (участвует в bookings_no_overlap_active).
+func (b Booking) OccupiesTime() bool { return !b.IsCancelled() }
+
+func (b Booking) IsBlock() bool { return b.Kind == BookingKindBlock }
+
+func (b Booking) IsClient() bool { return b.Kind == BookingKindClient }
+
+// SlotHold — интервал, который держится за клиентом до ExpiresAt; выкупается при создании записи по токену.
+type SlotHold struct {
+	ID uuid.UUID
+
+	StartAt time.Time
+	EndAt   time.Time
+
+	// Интервал с учётом буферов — его и занимает удержание.
+	OccupiedStartAt time.Time
+	OccupiedEndAt   time.Time
+
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// BookingReschedule — запись в истории переносов: прежнее и новое время сеанса.
+type BookingReschedule struct {
+	ID        uuid.UUID
+	BookingID uuid.UUID
+
+	OldStartAt time.Time
+	OldEndAt   time.Time
+	NewStartAt time.Time
+	NewEndAt   time.Time
+
+	Source        BookingActor
+	RescheduledBy *string // логин администратора; nil — перенёс клиент
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client — клиент студии из справочника; определяется телефоном и собирается из его записей.
+type Client struct {
+	ID    uuid.UUID
+	Name  string // из первой записи
+	Phone string
+
+	// Статистика по записям клиента, включая записи слитых дубликатов.
+	BookingsCount int        // неотменённые
+	VisitsCount   int        // состоявшиеся (completed)
+	NoShowCount   int        // неявки
+	LastVisitAt   *time.Time // начало последнего состоявшегося сеанса
+	TotalSpentRub int        // по состоявшимся, по цене на момент записи
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Closure — закрытие студии на диапазон дат [StartDate, EndDate] включительно.
+type Closure struct {
+	ID uuid.UUID
+
+	StartDate time.Time
+	EndDate   time.Time
+	Reason    string
+
+	CreatedAt time.Time
+}
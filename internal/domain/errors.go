@@ -2,6 +2,8 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"time"
 )
 
 var (
@@ -9,10 +11,38 @@ var (
 
 	ErrConflict = errors.New("conflict")
 
+	// ErrTimeTaken — интервал пересекается с другой записью или удержанием; частный случай ErrConflict.
+	ErrTimeTaken = fmt.Errorf("time taken: %w", ErrConflict)
+
 	ErrUnauthorized = errors.New("unauthorized")
+
+	ErrIdempotencyInProgress = errors.New("idempotency key in progress")
+
+	// ErrStudioClosed — день записи попал в закрытие студии.
+	ErrStudioClosed = errors.New("studio closed")
 )
 
 func (e ValidationError) With(field, message string) ValidationError {
 	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
 	return e
 }
+
+// BookingsConflictError — операция невозможна из-за уже существующих активных записей.
+type BookingsConflictError struct {
+	Bookings []Booking
+}
+
+func (e BookingsConflictError) Error() string {
+	return fmt.Sprintf("conflict with %d active booking(s)", len(e.Bookings))
+}
+
+func (e BookingsConflictError) Unwrap() error { return ErrConflict }
+
+// TooManyRequestsError — превышен лимит публичных запросов; повторить можно через RetryAfter.
+type TooManyRequestsError struct {
+	RetryAfter time.Duration
+}
+
+func (e TooManyRequestsError) Error() string {
+	return fmt.Sprintf("too many requests, retry after %s", e.RetryAfter)
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	RequestHash string
+
+	StatusCode   int
+	ResponseBody []byte
+
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	ExpiresAt   time.Time
+}
+
+func (r IdempotencyRecord) IsCompleted() bool { return r.CompletedAt != nil }
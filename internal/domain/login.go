@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginScope — по чему считаются неудачные попытки входа.
+type LoginScope string
+
+const (
+	LoginScopeIP       LoginScope = "ip"
+	LoginScopeUsername LoginScope = "username"
+)
+
+// LoginLockout — запись журнала временных блокировок входа.
+type LoginLockout struct {
+	ID uuid.UUID
+
+	Scope       LoginScope
+	Key         string
+	Failures    int
+	LockedUntil time.Time
+
+	CreatedAt time.Time
+}
+
+// TooManyAttemptsError — вход временно заблокирован; повторить можно через RetryAfter.
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("too many login attempts, retry after %s", e.RetryAfter)
+}
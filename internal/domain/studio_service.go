@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StudioService — услуга из каталога студии (тип съёмки с длительностью и ценой).
+type StudioService struct {
+	ID uuid.UUID
+
+	Name            string
+	Description     *string
+	DurationMinutes int
+	PriceRub        int
+	IsActive        bool
+
+	// RequiresApproval — записи на услугу ждут подтверждения администратором; nil — как в общей настройке.
+	RequiresApproval *bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
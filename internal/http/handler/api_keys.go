@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/service/apikey"
+)
+
+func (h *Handler) AdminListApiKeys(w http.ResponseWriter, r *http.Request) {
+	items, err := h.deps.APIKeys.List(r.Context())
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminListApiKeys")
+		return
+	}
+
+	out := make([]api.ApiKey, 0, len(items))
+	for _, k := range items {
+		out = append(out, toAPIKey(k))
+	}
+
+	writeJSON(w, http.StatusOK, api.ApiKeysResponse{Items: out})
+}
+
+func (h *Handler) AdminCreateApiKey(w http.ResponseWriter, r *http.Request) {
+	var body api.ApiKeyCreateRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.writeBadBody(w, r, "AdminCreateApiKey", err)
+		return
+	}
+
+	creator, _ := domain.AdminFromContext(r.Context())
+
+	scopes := make([]domain.Permission, 0, len(body.Scopes))
+	for _, sc := range body.Scopes {
+		scopes = append(scopes, domain.Permission(sc))
+	}
+
+	k, key, err := h.deps.APIKeys.Create(r.Context(), creator, apikey.CreateInput{
+		Name:      body.Name,
+		Scopes:    scopes,
+		ExpiresAt: body.ExpiresAt,
+	})
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminCreateApiKey")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, api.ApiKeyCreateResponse{
+		Key:    key,
+		ApiKey: toAPIKey(k),
+	})
+}
+
+func (h *Handler) AdminRevokeApiKey(w http.ResponseWriter, r *http.Request, keyId openapi_types.UUID) {
+	id := uuid.UUID(keyId)
+	if err := h.deps.APIKeys.Revoke(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "AdminRevokeApiKey")
+		return
+	}
+
+	h.deps.Logger.Info("api key revoked",
+		"request_id", middleware.GetReqID(r.Context()),
+		"api_key_id", id.String(),
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toAPIKey(k domain.APIKey) api.ApiKey {
+	scopes := make([]string, 0, len(k.Scopes))
+	for _, sc := range k.Scopes {
+		scopes = append(scopes, string(sc))
+	}
+
+	return api.ApiKey{
+		Id:         openapi_types.UUID(k.ID),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		CreatedBy:  k.CreatedByUsername,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
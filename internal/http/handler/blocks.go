@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/service/booking"
+)
+
+func (h *Handler) AdminListBlocksByDate(w http.ResponseWriter, r *http.Request, params api.AdminListBlocksByDateParams) {
+	items, err := h.deps.Booking.ListBlocksByDate(r.Context(), params.Date.Time)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminListBlocksByDate")
+		return
+	}
+
+	out := make([]api.Block, 0, len(items))
+	for _, b := range items {
+		out = append(out, h.toBlock(b))
+	}
+
+	writeJSON(w, http.StatusOK, api.AdminBlocksByDateResponse{
+		Date:  params.Date,
+		Items: out,
+	})
+}
+
+func (h *Handler) AdminCreateBlock(w http.ResponseWriter, r *http.Request) {
+	var body api.BlockCreateRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.deps.Logger.Info("bad request body",
+			"op", "AdminCreateBlock",
+			"request_id", middleware.GetReqID(r.Context()),
+			"err", err,
+		)
+		writeJSON(w, http.StatusBadRequest, api.ErrorResponse{
+			Code:    "bad_request",
+			Message: "Некорректное тело запроса",
+		})
+		return
+	}
+
+	b, err := h.deps.Booking.CreateBlock(r.Context(), booking.CreateBlockInput{
+		Date:            body.Date.Time,
+		StartTimeHHMM:   body.StartTime,
+		DurationMinutes: body.DurationMinutes,
+		Reason:          body.Reason,
+	})
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminCreateBlock")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, h.toBlock(b))
+}
+
+func (h *Handler) AdminDeleteBlock(w http.ResponseWriter, r *http.Request, blockId openapi_types.UUID) {
+	if err := h.deps.Booking.DeleteBlock(r.Context(), uuid.UUID(blockId)); err != nil {
+		h.writeServiceError(w, r, err, "AdminDeleteBlock")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) toBlock(b domain.Booking) api.Block {
+	startLocal := b.StartAt.In(h.loc)
+	endLocal := b.EndAt.In(h.loc)
+	d := dateOnly(startLocal, h.loc)
+
+	return api.Block{
+		Id:              openapi_types.UUID(b.ID),
+		Date:            openapi_types.Date{Time: d},
+		StartTime:       startLocal.Format("15:04"),
+		EndTime:         endLocal.Format("15:04"),
+		DurationMinutes: int(b.EndAt.Sub(b.StartAt) / time.Minute),
+		Reason:          b.Comment,
+		CreatedAt:       b.CreatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+)
+
+func (h *Handler) AdminListBookingRequests(w http.ResponseWriter, r *http.Request) {
+	items, err := h.deps.Booking.ListPendingBookings(r.Context())
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminListBookingRequests")
+		return
+	}
+
+	out := make([]api.BookingSummary, 0, len(items))
+	for _, b := range items {
+		out = append(out, h.toSummary(r.Context(), b))
+	}
+
+	writeJSON(w, http.StatusOK, api.AdminBookingRequestsResponse{Items: out})
+}
+
+func (h *Handler) AdminApproveBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params api.AdminApproveBookingParams) {
+	h.withIdempotency(w, r, "AdminApproveBooking", params.IdempotencyKey, func(w http.ResponseWriter, r *http.Request) {
+		h.adminTransitionBooking(w, r, uuid.UUID(bookingId), "AdminApproveBooking", h.deps.Booking.ApproveBooking)
+	})
+}
+
+func (h *Handler) AdminRejectBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params api.AdminRejectBookingParams) {
+	h.withIdempotency(w, r, "AdminRejectBooking", params.IdempotencyKey, func(w http.ResponseWriter, r *http.Request) {
+		h.adminRejectBooking(w, r, uuid.UUID(bookingId))
+	})
+}
+
+func (h *Handler) adminRejectBooking(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	var req api.CancelBookingRequest
+	if err := decodeJSONAllowEmpty(r, &req); err != nil {
+		h.writeBadBody(w, r, "AdminRejectBooking", err)
+		return
+	}
+
+	b, err := h.deps.Booking.RejectBooking(r.Context(), id, req.Reason)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminRejectBooking")
+		return
+	}
+
+	h.writeBookingDetail(w, r, b, "AdminRejectBooking")
+}
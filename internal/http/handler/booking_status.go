@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+)
+
+func (h *Handler) AdminConfirmBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params api.AdminConfirmBookingParams) {
+	h.withIdempotency(w, r, "AdminConfirmBooking", params.IdempotencyKey, func(w http.ResponseWriter, r *http.Request) {
+		h.adminTransitionBooking(w, r, uuid.UUID(bookingId), "AdminConfirmBooking", h.deps.Booking.ConfirmBooking)
+	})
+}
+
+func (h *Handler) AdminCompleteBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params api.AdminCompleteBookingParams) {
+	h.withIdempotency(w, r, "AdminCompleteBooking", params.IdempotencyKey, func(w http.ResponseWriter, r *http.Request) {
+		h.adminTransitionBooking(w, r, uuid.UUID(bookingId), "AdminCompleteBooking", h.deps.Booking.CompleteBooking)
+	})
+}
+
+func (h *Handler) AdminMarkNoShow(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params api.AdminMarkNoShowParams) {
+	h.withIdempotency(w, r, "AdminMarkNoShow", params.IdempotencyKey, func(w http.ResponseWriter, r *http.Request) {
+		h.adminTransitionBooking(w, r, uuid.UUID(bookingId), "AdminMarkNoShow", h.deps.Booking.MarkNoShow)
+	})
+}
+
+func (h *Handler) adminTransitionBooking(w http.ResponseWriter, r *http.Request, id uuid.UUID, op string, fn func(context.Context, uuid.UUID) (domain.Booking, error)) {
+	b, err := fn(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, op)
+		return
+	}
+
+	h.writeBookingDetail(w, r, b, op)
+}
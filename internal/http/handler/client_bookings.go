@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+)
+
+func (h *Handler) GetClientBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params api.GetClientBookingParams) {
+	b, err := h.deps.Booking.GetClientBooking(r.Context(), uuid.UUID(bookingId), params.Token)
+	if err != nil {
+		h.writeServiceError(w, r, err, "GetClientBooking")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.toClientBooking(b))
+}
+
+func (h *Handler) CancelClientBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID) {
+	var body api.ClientCancelBookingRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.writeBadBody(w, r, "CancelClientBooking", err)
+		return
+	}
+
+	b, err := h.deps.Booking.CancelClientBooking(r.Context(), uuid.UUID(bookingId), body.Token, body.Reason)
+	if err != nil {
+		h.writeServiceError(w, r, err, "CancelClientBooking")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.toClientBooking(b))
+}
+
+func (h *Handler) toClientBooking(b domain.Booking) api.ClientBooking {
+	startLocal := b.StartAt.In(h.loc)
+	endLocal := b.EndAt.In(h.loc)
+	d := dateOnly(startLocal, h.loc)
+
+	return api.ClientBooking{
+		Id:               openapi_types.UUID(b.ID),
+		Date:             openapi_types.Date{Time: d},
+		StartTime:        startLocal.Format("15:04"),
+		EndTime:          endLocal.Format("15:04"),
+		DurationMinutes:  int(b.EndAt.Sub(b.StartAt) / time.Minute),
+		ClientName:       b.ClientName,
+		ServiceName:      b.ServiceName,
+		PriceRub:         b.PriceRub,
+		Status:           h.toAPIStatus(b.Status),
+		PendingExpiresAt: b.PendingExpiresAt,
+		CreatedAt:        b.CreatedAt,
+		CancelledAt:      b.CancelledAt,
+		CancelSource:     toAPIBookingActor(b.CancelSource),
+	}
+}
+
+func toAPIBookingActor(s *domain.BookingActor) *api.BookingActor {
+	if s == nil {
+		return nil
+	}
+	out := api.BookingActor(*s)
+	return &out
+}
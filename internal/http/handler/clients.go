@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/service/clients"
+)
+
+func (h *Handler) AdminListClients(w http.ResponseWriter, r *http.Request, params api.AdminListClientsParams) {
+	q := clients.ListQuery{Search: deref(params.Q)}
+	if params.Limit != nil {
+		q.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		q.Offset = *params.Offset
+	}
+
+	items, err := h.deps.Clients.ListClients(r.Context(), q)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminListClients")
+		return
+	}
+
+	out := make([]api.Client, 0, len(items))
+	for _, c := range items {
+		out = append(out, toAPIClient(c))
+	}
+
+	writeJSON(w, http.StatusOK, api.AdminClientsResponse{Items: out})
+}
+
+func (h *Handler) AdminGetClient(w http.ResponseWriter, r *http.Request, clientId openapi_types.UUID) {
+	c, err := h.deps.Clients.GetClient(r.Context(), uuid.UUID(clientId))
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminGetClient")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIClient(c))
+}
+
+func (h *Handler) AdminMergeClients(w http.ResponseWriter, r *http.Request, clientId openapi_types.UUID, params api.AdminMergeClientsParams) {
+	h.withIdempotency(w, r, "AdminMergeClients", params.IdempotencyKey, func(w http.ResponseWriter, r *http.Request) {
+		h.adminMergeClients(w, r, uuid.UUID(clientId))
+	})
+}
+
+func (h *Handler) adminMergeClients(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	var req api.ClientMergeRequest
+	if err := decodeJSON(r, &req); err != nil {
+		h.writeBadBody(w, r, "AdminMergeClients", err)
+		return
+	}
+
+	duplicates := make([]uuid.UUID, 0, len(req.DuplicateIds))
+	for _, d := range req.DuplicateIds {
+		duplicates = append(duplicates, uuid.UUID(d))
+	}
+
+	c, err := h.deps.Clients.MergeClients(r.Context(), id, duplicates)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminMergeClients")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIClient(c))
+}
+
+func toAPIClient(c domain.Client) api.Client {
+	return api.Client{
+		Id:            openapi_types.UUID(c.ID),
+		Name:          c.Name,
+		Phone:         c.Phone,
+		BookingsCount: c.BookingsCount,
+		VisitsCount:   c.VisitsCount,
+		NoShowCount:   c.NoShowCount,
+		LastVisitAt:   c.LastVisitAt,
+		TotalSpentRub: c.TotalSpentRub,
+		CreatedAt:     c.CreatedAt,
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/service/closure"
+)
+
+func (h *Handler) AdminListClosures(w http.ResponseWriter, r *http.Request, params api.AdminListClosuresParams) {
+	items, err := h.deps.Closures.ListClosures(r.Context(), params.From.Time, params.To.Time)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminListClosures")
+		return
+	}
+
+	out := make([]api.Closure, 0, len(items))
+	for _, c := range items {
+		out = append(out, toClosure(c))
+	}
+
+	writeJSON(w, http.StatusOK, api.AdminClosuresResponse{Items: out})
+}
+
+func (h *Handler) AdminCreateClosure(w http.ResponseWriter, r *http.Request) {
+	var body api.ClosureCreateRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.deps.Logger.Info("bad request body",
+			"op", "AdminCreateClosure",
+			"request_id", middleware.GetReqID(r.Context()),
+			"err", err,
+		)
+		writeJSON(w, http.StatusBadRequest, api.ErrorResponse{
+			Code:    "bad_request",
+			Message: "Некорректное тело запроса",
+		})
+		return
+	}
+
+	c, err := h.deps.Closures.CreateClosure(r.Context(), closure.CreateClosureInput{
+		StartDate: body.StartDate.Time,
+		EndDate:   body.EndDate.Time,
+		Reason:    body.Reason,
+	})
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminCreateClosure")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toClosure(c))
+}
+
+func (h *Handler) AdminDeleteClosure(w http.ResponseWriter, r *http.Request, closureId openapi_types.UUID) {
+	if err := h.deps.Closures.DeleteClosure(r.Context(), uuid.UUID(closureId)); err != nil {
+		h.writeServiceError(w, r, err, "AdminDeleteClosure")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toClosure(c domain.Closure) api.Closure {
+	return api.Closure{
+		Id:        openapi_types.UUID(c.ID),
+		StartDate: openapi_types.Date{Time: c.StartDate},
+		EndDate:   openapi_types.Date{Time: c.EndDate},
+		Reason:    c.Reason,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
@@ -14,20 +19,41 @@ import (
 
 	"photannie/internal/api"
 	"photannie/internal/domain"
+	"photannie/internal/http/session"
+	"photannie/internal/service/admin"
+	"photannie/internal/service/apikey"
 	"photannie/internal/service/booking"
+	"photannie/internal/service/catalog"
+	"photannie/internal/service/clients"
+	"photannie/internal/service/closure"
+	"photannie/internal/service/idempotency"
+	"photannie/internal/service/loginguard"
+	"photannie/internal/service/oidclogin"
 )
 
 type SessionStore interface {
-	New() (string, error)
-	Exists(sessionID string) bool
+	New(ctx context.Context, p session.NewParams) (domain.AdminSession, error)
+	CompleteMFA(ctx context.Context, token string) (domain.AdminSession, error)
+	Delete(ctx context.Context, token string) error
+	List(ctx context.Context) ([]domain.AdminSession, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
 }
 
 type Deps struct {
-	Booking booking.Service
+	Booking     booking.Service
+	Idempotency idempotency.Service
+	Closures    closure.Service
+	Catalog     catalog.Service
+	Clients     clients.Service
 
-	AdminPassword string
-	CookieName    string
-	SecureCookie  bool
+	Admins       admin.Service
+	LoginGuard   loginguard.Service
+	APIKeys      apikey.Service
+	CookieName   string
+	SecureCookie bool
+
+	OIDC             oidclogin.Service // nil — вход через OIDC выключен
+	OIDCPostLoginURL string
 
 	Sessions SessionStore
 	Logger   *slog.Logger
@@ -45,6 +71,9 @@ func New(deps Deps) *Handler {
 	if deps.CookieName == "" {
 		deps.CookieName = "photannie_session"
 	}
+	if deps.OIDCPostLoginURL == "" {
+		deps.OIDCPostLoginURL = "/admin"
+	}
 
 	loc, err := time.LoadLocation("Europe/Moscow")
 	if err != nil {
@@ -83,21 +112,94 @@ func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) GetFreeSlotsByDate(w http.ResponseWriter, r *http.Request, params api.GetFreeSlotsByDateParams) {
-	date := params.Date.Time
+	q := booking.FreeSlotsQuery{Date: params.Date.Time}
+	if params.DurationMinutes != nil {
+		q.DurationMinutes = *params.DurationMinutes
+	}
+	if params.ServiceId != nil {
+		id := uuid.UUID(*params.ServiceId)
+		q.ServiceID = &id
+	}
 
-	free, err := h.deps.Booking.GetFreeSlots(r.Context(), date)
+	free, err := h.deps.Booking.GetFreeSlots(r.Context(), q)
 	if err != nil {
 		h.writeServiceError(w, r, err, "GetFreeSlotsByDate")
 		return
 	}
 
 	writeJSON(w, http.StatusOK, api.FreeSlotsResponse{
-		Date:      params.Date,
-		FreeSlots: free,
+		Date:            params.Date,
+		DurationMinutes: free.DurationMinutes,
+		FreeSlots:       free.Starts,
 	})
 }
 
-func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request, _ api.CreateBookingParams) {
+func (h *Handler) GetAvailability(w http.ResponseWriter, r *http.Request, params api.GetAvailabilityParams) {
+	q := booking.AvailabilityQuery{From: params.From.Time, To: params.To.Time}
+	if params.DurationMinutes != nil {
+		q.DurationMinutes = *params.DurationMinutes
+	}
+	if params.ServiceId != nil {
+		id := uuid.UUID(*params.ServiceId)
+		q.ServiceID = &id
+	}
+
+	av, err := h.deps.Booking.GetAvailability(r.Context(), q)
+	if err != nil {
+		h.writeServiceError(w, r, err, "GetAvailability")
+		return
+	}
+
+	days := make([]api.DayAvailability, 0, len(av.Days))
+	for _, d := range av.Days {
+		days = append(days, api.DayAvailability{
+			Date:      openapi_types.Date{Time: d.Date},
+			Status:    api.DayAvailabilityStatus(d.Status),
+			FreeSlots: d.FreeSlots,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, api.AvailabilityResponse{
+		From:            openapi_types.Date{Time: av.From},
+		To:              openapi_types.Date{Time: av.To},
+		DurationMinutes: av.DurationMinutes,
+		Days:            days,
+	})
+}
+
+func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request, params api.CreateBookingParams) {
+	h.withIdempotencySecret(w, r, "CreateBooking", params.IdempotencyKey, &idempotentSecret{
+		strip:   stripManageToken,
+		restore: h.reissueManageToken,
+	}, h.createBooking)
+}
+
+// stripManageToken — в idempotency_keys ответ createBooking сохраняется без токена: в БД живёт только его хэш.
+func stripManageToken(body []byte) ([]byte, error) {
+	var resp api.BookingCreateResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	resp.ManageToken = ""
+	return json.Marshal(resp)
+}
+
+// reissueManageToken — исходный токен при повторе не восстановить, поэтому выдаётся новый;
+// прежняя ссылка перестаёт действовать, как и положено ссылке, которую клиент мог не получить.
+func (h *Handler) reissueManageToken(r *http.Request, body []byte) ([]byte, error) {
+	var resp api.BookingCreateResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	token, err := h.deps.Booking.ReissueManageToken(r.Context(), uuid.UUID(resp.Id))
+	if err != nil {
+		return nil, err
+	}
+	resp.ManageToken = token
+	return json.Marshal(resp)
+}
+
+func (h *Handler) createBooking(w http.ResponseWriter, r *http.Request) {
 	var body api.BookingCreateRequest
 	if err := decodeJSON(r, &body); err != nil {
 		h.deps.Logger.Info("bad request body",
@@ -112,20 +214,31 @@ func (h *Handler) CreateBooking(w http.ResponseWriter, r *http.Request, _ api.Cr
 		return
 	}
 
-	created, err := h.deps.Booking.CreateBooking(r.Context(), booking.CreateBookingInput{
-		Date:            body.Date.Time,
-		StartTimeHHMM:   body.StartTime,
-		DurationMinutes: body.DurationMinutes,
-		ClientName:      body.Name,
-		ClientPhone:     body.Phone,
-		Comment:         body.Comment,
-	})
+	in := booking.CreateBookingInput{
+		Date:          body.Date.Time,
+		StartTimeHHMM: body.StartTime,
+		ClientName:    body.Name,
+		ClientPhone:   body.Phone,
+		Comment:       body.Comment,
+	}
+	if body.DurationMinutes != nil {
+		in.DurationMinutes = *body.DurationMinutes
+	}
+	if body.ServiceId != nil {
+		id := uuid.UUID(*body.ServiceId)
+		in.ServiceID = &id
+	}
+	if body.HoldToken != nil {
+		in.HoldToken = *body.HoldToken
+	}
+
+	created, token, err := h.deps.Booking.CreateBooking(r.Context(), in)
 	if err != nil {
 		h.writeServiceError(w, r, err, "CreateBooking")
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, h.toCreateResponse(created))
+	writeJSON(w, http.StatusCreated, h.toCreateResponse(created, token))
 }
 
 func (h *Handler) AdminSessionLogin(w http.ResponseWriter, r *http.Request) {
@@ -143,30 +256,74 @@ func (h *Handler) AdminSessionLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if body.Password == "" || body.Password != h.deps.AdminPassword {
+	username := admin.DefaultUsername
+	if body.Username != nil {
+		username = *body.Username
+	}
+
+	ip := clientIP(r)
+	if err := h.deps.LoginGuard.Check(r.Context(), ip, username); err != nil {
+		h.writeServiceError(w, r, err, "AdminSessionLogin")
+		return
+	}
+
+	a, err := h.deps.Admins.Authenticate(r.Context(), username, body.Password)
+	if errors.Is(err, domain.ErrUnauthorized) {
+		if err := h.deps.LoginGuard.RecordFailure(r.Context(), ip, username); err != nil {
+			h.writeServiceError(w, r, err, "AdminSessionLogin")
+			return
+		}
 		writeJSON(w, http.StatusUnauthorized, api.ErrorResponse{
 			Code:    "admin_unauthorized",
-			Message: "Неверный пароль",
+			Message: "Неверный логин или пароль",
 		})
 		return
 	}
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminSessionLogin")
+		return
+	}
+	if err := h.deps.LoginGuard.RecordSuccess(r.Context(), ip, username); err != nil {
+		h.writeServiceError(w, r, err, "AdminSessionLogin")
+		return
+	}
 
+	sess, ok := h.startSession(w, r, a, "AdminSessionLogin")
+	if !ok {
+		return
+	}
+	if sess.MFAPending {
+		writeJSON(w, http.StatusOK, api.AdminLoginMFAResponse{MfaRequired: true})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// startSession создаёт сессию администратора и ставит cookie; при ошибке ответ уже записан.
+// При подключённом TOTP сессия ждёт второго фактора.
+func (h *Handler) startSession(w http.ResponseWriter, r *http.Request, a domain.Admin, op string) (domain.AdminSession, bool) {
 	if h.deps.Sessions == nil {
 		h.deps.Logger.Error("session store is nil",
-			"op", "AdminSessionLogin",
+			"op", op,
 			"request_id", middleware.GetReqID(r.Context()),
 		)
 		writeJSON(w, http.StatusInternalServerError, api.ErrorResponse{
 			Code:    "internal_error",
 			Message: "Ошибка сервера",
 		})
-		return
+		return domain.AdminSession{}, false
 	}
 
-	sid, err := h.deps.Sessions.New()
+	sess, err := h.deps.Sessions.New(r.Context(), session.NewParams{
+		Admin:      a,
+		IP:         clientIP(r),
+		UserAgent:  r.UserAgent(),
+		MFAPending: a.TOTPEnabled(),
+	})
 	if err != nil {
 		h.deps.Logger.Error("failed to create session",
-			"op", "AdminSessionLogin",
+			"op", op,
 			"request_id", middleware.GetReqID(r.Context()),
 			"err", err,
 		)
@@ -174,19 +331,11 @@ func (h *Handler) AdminSessionLogin(w http.ResponseWriter, r *http.Request) {
 			Code:    "internal_error",
 			Message: "Ошибка сервера",
 		})
-		return
+		return domain.AdminSession{}, false
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     h.deps.CookieName,
-		Value:    sid,
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteStrictMode,
-		Secure:   h.deps.SecureCookie,
-	})
-
-	w.WriteHeader(http.StatusNoContent)
+	h.setSessionCookie(w, sess)
+	return sess, true
 }
 
 func (h *Handler) AdminListBookingsByDate(w http.ResponseWriter, r *http.Request, params api.AdminListBookingsByDateParams) {
@@ -206,7 +355,7 @@ func (h *Handler) AdminListBookingsByDate(w http.ResponseWriter, r *http.Request
 		if !matchesStatusFilter(b, filter) {
 			continue
 		}
-		out = append(out, h.toSummary(b))
+		out = append(out, h.toSummary(r.Context(), b))
 	}
 
 	writeJSON(w, http.StatusOK, api.AdminBookingsByDateResponse{
@@ -217,10 +366,14 @@ func (h *Handler) AdminListBookingsByDate(w http.ResponseWriter, r *http.Request
 
 func matchesStatusFilter(b domain.Booking, f api.BookingStatusFilter) bool {
 	switch f {
-	case api.BookingStatusFilterCancelled:
-		return b.Status == domain.BookingStatusCancelled
 	case api.BookingStatusFilterActive:
-		return b.Status == domain.BookingStatusActive
+		return b.OccupiesTime()
+	case api.BookingStatusFilterPending,
+		api.BookingStatusFilterConfirmed,
+		api.BookingStatusFilterCompleted,
+		api.BookingStatusFilterNoShow,
+		api.BookingStatusFilterCancelled:
+		return string(b.Status) == string(f)
 	case api.BookingStatusFilterAll:
 		fallthrough
 	default:
@@ -237,12 +390,16 @@ func (h *Handler) AdminGetBooking(w http.ResponseWriter, r *http.Request, bookin
 		return
 	}
 
-	writeJSON(w, http.StatusOK, h.toDetail(b))
+	h.writeBookingDetail(w, r, b, "AdminGetBooking")
 }
 
-func (h *Handler) AdminCancelBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, _ api.AdminCancelBookingParams) {
-	id := uuid.UUID(bookingId)
+func (h *Handler) AdminCancelBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params api.AdminCancelBookingParams) {
+	h.withIdempotency(w, r, "AdminCancelBooking", params.IdempotencyKey, func(w http.ResponseWriter, r *http.Request) {
+		h.adminCancelBooking(w, r, uuid.UUID(bookingId))
+	})
+}
 
+func (h *Handler) adminCancelBooking(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
 	var req api.CancelBookingRequest
 	if err := decodeJSONAllowEmpty(r, &req); err != nil {
 		h.deps.Logger.Info("bad request body",
@@ -264,81 +421,124 @@ func (h *Handler) AdminCancelBooking(w http.ResponseWriter, r *http.Request, boo
 		return
 	}
 
-	writeJSON(w, http.StatusOK, h.toDetail(b))
+	h.writeBookingDetail(w, r, b, "AdminCancelBooking")
 }
 
 func (h *Handler) toAPIStatus(s domain.BookingStatus) api.BookingStatus {
 	switch s {
+	case domain.BookingStatusPending:
+		return api.BookingStatusPending
+	case domain.BookingStatusCompleted:
+		return api.BookingStatusCompleted
+	case domain.BookingStatusNoShow:
+		return api.BookingStatusNoShow
 	case domain.BookingStatusCancelled:
 		return api.BookingStatusCancelled
-	case domain.BookingStatusActive:
+	case domain.BookingStatusConfirmed:
 		fallthrough
 	default:
-		return api.BookingStatusActive
+		return api.BookingStatusConfirmed
 	}
 }
 
-func (h *Handler) toCreateResponse(b domain.Booking) api.BookingCreateResponse {
+func (h *Handler) toCreateResponse(b domain.Booking, manageToken string) api.BookingCreateResponse {
 	startLocal := b.StartAt.In(h.loc)
 	endLocal := b.EndAt.In(h.loc)
 	d := dateOnly(startLocal, h.loc)
 
 	return api.BookingCreateResponse{
-		Id:              openapi_types.UUID(b.ID),
-		Date:            openapi_types.Date{Time: d},
-		StartTime:       startLocal.Format("15:04"),
-		EndTime:         endLocal.Format("15:04"),
-		DurationMinutes: int(b.EndAt.Sub(b.StartAt) / time.Minute),
-		Status:          h.toAPIStatus(b.Status),
-		CreatedAt:       b.CreatedAt,
+		Id:               openapi_types.UUID(b.ID),
+		Date:             openapi_types.Date{Time: d},
+		StartTime:        startLocal.Format("15:04"),
+		EndTime:          endLocal.Format("15:04"),
+		DurationMinutes:  int(b.EndAt.Sub(b.StartAt) / time.Minute),
+		Status:           h.toAPIStatus(b.Status),
+		PendingExpiresAt: b.PendingExpiresAt,
+		CreatedAt:        b.CreatedAt,
+		ManageToken:      manageToken,
 	}
 }
 
-func (h *Handler) toSummary(b domain.Booking) api.BookingSummary {
+func (h *Handler) toSummary(ctx context.Context, b domain.Booking) api.BookingSummary {
 	startLocal := b.StartAt.In(h.loc)
 	endLocal := b.EndAt.In(h.loc)
 	d := dateOnly(startLocal, h.loc)
 
 	return api.BookingSummary{
-		Id:              openapi_types.UUID(b.ID),
-		Date:            openapi_types.Date{Time: d},
-		StartTime:       startLocal.Format("15:04"),
-		EndTime:         endLocal.Format("15:04"),
-		DurationMinutes: int(b.EndAt.Sub(b.StartAt) / time.Minute),
-		Status:          h.toAPIStatus(b.Status),
-		CreatedAt:       b.CreatedAt,
+		Id:               openapi_types.UUID(b.ID),
+		Date:             openapi_types.Date{Time: d},
+		StartTime:        startLocal.Format("15:04"),
+		EndTime:          endLocal.Format("15:04"),
+		DurationMinutes:  int(b.EndAt.Sub(b.StartAt) / time.Minute),
+		Status:           h.toAPIStatus(b.Status),
+		PendingExpiresAt: b.PendingExpiresAt,
+		CreatedAt:        b.CreatedAt,
 
 		ClientName:  b.ClientName,
-		ClientPhone: b.ClientPhone,
+		ClientPhone: clientPhoneFor(ctx, b.ClientPhone),
 		Comment:     b.Comment,
 
+		ServiceId:   toAPIUUIDPtr(b.ServiceID),
+		ServiceName: b.ServiceName,
+		PriceRub:    b.PriceRub,
+
 		CancelledAt: b.CancelledAt,
 	}
 }
 
-func (h *Handler) toDetail(b domain.Booking) api.BookingDetail {
+func (h *Handler) toDetail(ctx context.Context, b domain.Booking) api.BookingDetail {
 	startLocal := b.StartAt.In(h.loc)
 	endLocal := b.EndAt.In(h.loc)
 	d := dateOnly(startLocal, h.loc)
 
 	return api.BookingDetail{
-		Id:              openapi_types.UUID(b.ID),
-		Date:            openapi_types.Date{Time: d},
-		StartTime:       startLocal.Format("15:04"),
-		EndTime:         endLocal.Format("15:04"),
-		DurationMinutes: int(b.EndAt.Sub(b.StartAt) / time.Minute),
-		Status:          h.toAPIStatus(b.Status),
-		CreatedAt:       b.CreatedAt,
+		Id:               openapi_types.UUID(b.ID),
+		Date:             openapi_types.Date{Time: d},
+		StartTime:        startLocal.Format("15:04"),
+		EndTime:          endLocal.Format("15:04"),
+		DurationMinutes:  int(b.EndAt.Sub(b.StartAt) / time.Minute),
+		Status:           h.toAPIStatus(b.Status),
+		PendingExpiresAt: b.PendingExpiresAt,
+		CreatedAt:        b.CreatedAt,
 
 		ClientName:  b.ClientName,
-		ClientPhone: b.ClientPhone,
+		ClientPhone: clientPhoneFor(ctx, b.ClientPhone),
 		Comment:     b.Comment,
 
+		ServiceId:   toAPIUUIDPtr(b.ServiceID),
+		ServiceName: b.ServiceName,
+		PriceRub:    b.PriceRub,
+		ClientId:    toAPIUUIDPtr(b.ClientID),
+
+		ConfirmedAt:  b.ConfirmedAt,
+		CompletedAt:  b.CompletedAt,
+		NoShowAt:     b.NoShowAt,
 		CancelledAt:  b.CancelledAt,
 		CancelReason: b.CancelReason,
+		CancelledBy:  b.CancelledBy,
+		CancelSource: toAPIBookingActor(b.CancelSource),
 	}
 }
 
+// clientPhoneFor маскирует телефон для ролей без доступа к персональным данным: +7*******67.
+func clientPhoneFor(ctx context.Context, phone string) string {
+	if a, ok := domain.AdminFromContext(ctx); ok && a.Can(domain.PermClientPII) {
+		return phone
+	}
+	if len(phone) <= 4 {
+		return strings.Repeat("*", len(phone))
+	}
+	return phone[:2] + strings.Repeat("*", len(phone)-4) + phone[len(phone)-2:]
+}
+
+func toAPIUUIDPtr(id *uuid.UUID) *openapi_types.UUID {
+	if id == nil {
+		return nil
+	}
+	v := openapi_types.UUID(*id)
+	return &v
+}
+
 func dateOnly(t time.Time, loc *time.Location) time.Time {
 	tt := t.In(loc)
 	return time.Date(tt.Year(), tt.Month(), tt.Day(), 0, 0, 0, 0, loc)
@@ -366,7 +566,74 @@ func (h *Handler) writeServiceError(w http.ResponseWriter, r *http.Request, err
 		return
 	}
 
-	if errors.Is(err, domain.ErrConflict) {
+	if errors.Is(err, domain.ErrIdempotencyInProgress) {
+		h.deps.Logger.Info("idempotency key in progress", "op", op, "request_id", reqID, "err", err)
+		writeJSON(w, http.StatusConflict, api.ErrorResponse{
+			Code:    "idempotency_in_progress",
+			Message: "Запрос с этим ключом ещё обрабатывается, повторите позже",
+		})
+		return
+	}
+
+	var tmerr domain.TooManyAttemptsError
+	if errors.As(err, &tmerr) {
+		retry := int(math.Ceil(tmerr.RetryAfter.Seconds()))
+		if retry < 1 {
+			retry = 1
+		}
+
+		h.deps.Logger.Info("too many attempts", "op", op, "request_id", reqID, "err", err)
+		w.Header().Set("Retry-After", strconv.Itoa(retry))
+		writeJSON(w, http.StatusTooManyRequests, api.ErrorResponse{
+			Code:    "too_many_attempts",
+			Message: "Слишком много попыток входа, повторите позже",
+		})
+		return
+	}
+
+	var trerr domain.TooManyRequestsError
+	if errors.As(err, &trerr) {
+		retry := int(math.Ceil(trerr.RetryAfter.Seconds()))
+		if retry < 1 {
+			retry = 1
+		}
+
+		h.deps.Logger.Info("too many requests", "op", op, "request_id", reqID, "err", err)
+		w.Header().Set("Retry-After", strconv.Itoa(retry))
+		writeJSON(w, http.StatusTooManyRequests, api.ErrorResponse{
+			Code:    "too_many_requests",
+			Message: "Слишком много запросов, повторите позже",
+		})
+		return
+	}
+
+	var terr domain.BookingTransitionError
+	if errors.As(err, &terr) {
+		h.deps.Logger.Info("invalid status transition", "op", op, "request_id", reqID, "err", err)
+		writeJSON(w, http.StatusConflict, api.ErrorResponse{
+			Code:    "invalid_transition",
+			Message: fmt.Sprintf("Запись в состоянии %s нельзя перевести в %s", terr.From, terr.To),
+		})
+		return
+	}
+
+	var bcerr domain.BookingsConflictError
+	if errors.As(err, &bcerr) {
+		bookings := make([]api.BookingSummary, 0, len(bcerr.Bookings))
+		for _, b := range bcerr.Bookings {
+			bookings = append(bookings, h.toSummary(r.Context(), b))
+		}
+
+		h.deps.Logger.Info("bookings conflict", "op", op, "request_id", reqID, "err", err)
+		writeJSON(w, http.StatusConflict, api.BookingsConflictResponse{
+			Code:     "bookings_conflict",
+			Message:  "На выбранные даты есть активные записи",
+			Bookings: bookings,
+		})
+		return
+	}
+
+	if errors.Is(err, domain.ErrTimeTaken) {
 		h.deps.Logger.Info("time conflict", "op", op, "request_id", reqID, "err", err)
 		writeJSON(w, http.StatusConflict, api.ErrorResponse{
 			Code:    "time_taken",
@@ -375,6 +642,15 @@ func (h *Handler) writeServiceError(w http.ResponseWriter, r *http.Request, err
 		return
 	}
 
+	if errors.Is(err, domain.ErrConflict) {
+		h.deps.Logger.Info("conflict", "op", op, "request_id", reqID, "err", err)
+		writeJSON(w, http.StatusConflict, api.ErrorResponse{
+			Code:    "conflict",
+			Message: "Конфликт с уже существующими данными",
+		})
+		return
+	}
+
 	if errors.Is(err, domain.ErrNotFound) {
 		h.deps.Logger.Info("not found", "op", op, "request_id", reqID, "err", err)
 		writeJSON(w, http.StatusNotFound, api.ErrorResponse{
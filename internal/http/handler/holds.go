@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/service/booking"
+)
+
+func (h *Handler) CreateSlotHold(w http.ResponseWriter, r *http.Request, params api.CreateSlotHoldParams) {
+	h.withIdempotency(w, r, "CreateSlotHold", params.IdempotencyKey, h.createSlotHold)
+}
+
+func (h *Handler) createSlotHold(w http.ResponseWriter, r *http.Request) {
+	var body api.SlotHoldRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.writeBadBody(w, r, "CreateSlotHold", err)
+		return
+	}
+
+	in := booking.CreateHoldInput{
+		Date:          body.Date.Time,
+		StartTimeHHMM: body.StartTime,
+		Requester:     clientIP(r),
+	}
+	if body.DurationMinutes != nil {
+		in.DurationMinutes = *body.DurationMinutes
+	}
+	if body.ServiceId != nil {
+		id := uuid.UUID(*body.ServiceId)
+		in.ServiceID = &id
+	}
+	if body.ReplaceHoldToken != nil {
+		in.ReplaceToken = *body.ReplaceHoldToken
+	}
+
+	hold, token, err := h.deps.Booking.CreateHold(r.Context(), in)
+	if err != nil {
+		h.writeServiceError(w, r, err, "CreateSlotHold")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, h.toSlotHoldResponse(hold, token))
+}
+
+func (h *Handler) toSlotHoldResponse(hold domain.SlotHold, token string) api.SlotHoldResponse {
+	startLocal := hold.StartAt.In(h.loc)
+	endLocal := hold.EndAt.In(h.loc)
+
+	return api.SlotHoldResponse{
+		HoldToken:       token,
+		Date:            openapi_types.Date{Time: dateOnly(startLocal, h.loc)},
+		StartTime:       startLocal.Format("15:04"),
+		EndTime:         endLocal.Format("15:04"),
+		DurationMinutes: int(hold.EndAt.Sub(hold.StartAt) / time.Minute),
+		ExpiresAt:       hold.ExpiresAt,
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/service/idempotency"
+)
+
+const (
+	idempotencyKeyMinLen = 8
+	idempotencyKeyMaxLen = 128
+
+	// idempotencyMaxBody — тело читается целиком ради отпечатка запроса, поэтому размер ограничен.
+	idempotencyMaxBody = 1 << 20
+
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// idempotentSecret не даёт секрету из успешного ответа попасть в idempotency_keys: strip убирает его
+// из сохраняемого тела, restore при повторе выдаёт взамен новый.
+type idempotentSecret struct {
+	strip   func(body []byte) ([]byte, error)
+	restore func(r *http.Request, body []byte) ([]byte, error)
+}
+
+// withIdempotency выполняет next не более одного раза на пару (op, key): повтор с тем же ключом
+// и телом получает сохранённый ответ, ответы 5xx и 429 не сохраняются, чтобы клиент мог повторить запрос.
+func (h *Handler) withIdempotency(w http.ResponseWriter, r *http.Request, op string, key *api.IdempotencyKey, next func(w http.ResponseWriter, r *http.Request)) {
+	h.withIdempotencySecret(w, r, op, key, nil, next)
+}
+
+func (h *Handler) withIdempotencySecret(w http.ResponseWriter, r *http.Request, op string, key *api.IdempotencyKey, secret *idempotentSecret, next func(w http.ResponseWriter, r *http.Request)) {
+	if key == nil || h.deps.Idempotency == nil {
+		next(w, r)
+		return
+	}
+
+	if len(*key) < idempotencyKeyMinLen || len(*key) > idempotencyKeyMaxLen {
+		h.writeServiceError(w, r, domain.ValidationError{}.Add("Idempotency-Key", "Ключ должен содержать от 8 до 128 символов"), op)
+		return
+	}
+
+	var raw []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		b, err := io.ReadAll(http.MaxBytesReader(w, r.Body, idempotencyMaxBody))
+		_ = r.Body.Close()
+		if err != nil {
+			h.deps.Logger.Info("bad request body",
+				"op", op,
+				"request_id", middleware.GetReqID(r.Context()),
+				"err", err,
+			)
+			msg := "Некорректное тело запроса"
+			var mberr *http.MaxBytesError
+			if errors.As(err, &mberr) {
+				msg = "Слишком большое тело запроса"
+			}
+			writeJSON(w, http.StatusBadRequest, api.ErrorResponse{
+				Code:    "bad_request",
+				Message: msg,
+			})
+			return
+		}
+		raw = b
+		r.Body = io.NopCloser(bytes.NewReader(raw))
+	}
+
+	fingerprint := make([]byte, 0, len(r.Method)+len(r.URL.Path)+len(raw)+2)
+	fingerprint = append(fingerprint, r.Method...)
+	fingerprint = append(fingerprint, ' ')
+	fingerprint = append(fingerprint, r.URL.Path...)
+	fingerprint = append(fingerprint, '\n')
+	fingerprint = append(fingerprint, raw...)
+
+	scope := idempotencyScope(r, op)
+	replay, err := h.deps.Idempotency.Begin(r.Context(), idempotency.BeginInput{
+		Scope:   scope,
+		Key:     *key,
+		Request: fingerprint,
+	})
+	if err != nil {
+		h.writeServiceError(w, r, err, op)
+		return
+	}
+	if replay != nil {
+		body := replay.Body
+		if secret != nil && isSuccess(replay.StatusCode) {
+			body, err = secret.restore(r, body)
+			if err != nil {
+				h.writeServiceError(w, r, err, op)
+				return
+			}
+		}
+
+		w.Header().Set(idempotentReplayedHeader, "true")
+		if len(body) > 0 {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		}
+		w.WriteHeader(replay.StatusCode)
+		_, _ = w.Write(body)
+		return
+	}
+
+	rec := &responseRecorder{ResponseWriter: w}
+	next(rec, r)
+
+	// Запрос мог упереться в таймаут, но результат всё равно нужно зафиксировать.
+	ctx := context.WithoutCancel(r.Context())
+	if rec.status == 0 || rec.status == http.StatusTooManyRequests || rec.status >= http.StatusInternalServerError {
+		_ = h.deps.Idempotency.Release(ctx, scope, *key)
+		return
+	}
+	body := rec.body.Bytes()
+	if secret != nil && isSuccess(rec.status) {
+		body, err = secret.strip(body)
+		if err != nil {
+			h.deps.Logger.Error("idempotency strip secret failed", "op", op, "err", err)
+			_ = h.deps.Idempotency.Release(ctx, scope, *key)
+			return
+		}
+	}
+	_ = h.deps.Idempotency.Complete(ctx, scope, *key, idempotency.Response{
+		StatusCode: rec.status,
+		Body:       body,
+	})
+}
+
+// idempotencyScope — операция и, для административных операций, вызывающий (API-ключ или администратор):
+// одинаковые ключи разных администраторов не видят ответов друг друга. Публичный клиент при повторе
+// может прийти с другого IP, поэтому публичные операции ограничены только операцией, а чужой ключ
+// с другим телом отсекается отпечатком запроса.
+func idempotencyScope(r *http.Request, op string) string {
+	if a, ok := domain.AdminFromContext(r.Context()); ok {
+		if a.APIKeyID != nil {
+			return op + ":api_key:" + a.APIKeyID.String()
+		}
+		return op + ":admin:" + a.ID.String()
+	}
+	return op
+}
+
+func isSuccess(status int) bool {
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(code int) {
+	if rr.status == 0 {
+		rr.status = code
+	}
+	rr.ResponseWriter.WriteHeader(code)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if rr.status == 0 {
+		rr.status = http.StatusOK
+	}
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
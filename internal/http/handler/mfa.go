@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/http/mw"
+)
+
+func (h *Handler) AdminSessionMfa(w http.ResponseWriter, r *http.Request) {
+	var body api.MfaCodeRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.writeBadBody(w, r, "AdminSessionMfa", err)
+		return
+	}
+
+	sess, ok := mw.AdminSessionFromContext(r.Context())
+	if !ok || !sess.MFAPending {
+		h.writeServiceError(w, r, domain.ValidationError{}.Add("session", "Сессия не ожидает второго фактора"), "AdminSessionMfa")
+		return
+	}
+
+	ip := clientIP(r)
+	if err := h.deps.LoginGuard.Check(r.Context(), ip, sess.AdminUsername); err != nil {
+		h.writeServiceError(w, r, err, "AdminSessionMfa")
+		return
+	}
+
+	err := h.deps.Admins.VerifySecondFactor(r.Context(), sess.AdminID, deref(body.Code), deref(body.RecoveryCode))
+	if errors.Is(err, domain.ErrUnauthorized) {
+		if err := h.deps.LoginGuard.RecordFailure(r.Context(), ip, sess.AdminUsername); err != nil {
+			h.writeServiceError(w, r, err, "AdminSessionMfa")
+			return
+		}
+		writeJSON(w, http.StatusUnauthorized, api.ErrorResponse{
+			Code:    "admin_unauthorized",
+			Message: "Неверный код",
+		})
+		return
+	}
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminSessionMfa")
+		return
+	}
+	if err := h.deps.LoginGuard.RecordSuccess(r.Context(), ip, sess.AdminUsername); err != nil {
+		h.writeServiceError(w, r, err, "AdminSessionMfa")
+		return
+	}
+
+	done, err := h.deps.Sessions.CompleteMFA(r.Context(), sess.Token)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminSessionMfa")
+		return
+	}
+
+	h.setSessionCookie(w, done)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) AdminGetMfaStatus(w http.ResponseWriter, r *http.Request) {
+	a, _ := domain.AdminFromContext(r.Context())
+
+	st, err := h.deps.Admins.MFAStatus(r.Context(), a.ID)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminGetMfaStatus")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.MfaStatusResponse{
+		TotpEnabled:       st.TOTPEnabled,
+		RecoveryCodesLeft: st.RecoveryCodesLeft,
+	})
+}
+
+func (h *Handler) AdminBeginTotp(w http.ResponseWriter, r *http.Request) {
+	a, _ := domain.AdminFromContext(r.Context())
+
+	en, err := h.deps.Admins.BeginTOTP(r.Context(), a.ID)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminBeginTotp")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.TotpEnrollmentResponse{
+		Secret:     en.Secret,
+		OtpauthUri: en.URI,
+	})
+}
+
+func (h *Handler) AdminConfirmTotp(w http.ResponseWriter, r *http.Request) {
+	var body api.TotpCodeRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.writeBadBody(w, r, "AdminConfirmTotp", err)
+		return
+	}
+
+	a, _ := domain.AdminFromContext(r.Context())
+
+	codes, err := h.deps.Admins.ConfirmTOTP(r.Context(), a.ID, body.Code)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminConfirmTotp")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (h *Handler) AdminDisableTotp(w http.ResponseWriter, r *http.Request) {
+	var body api.MfaCodeRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.writeBadBody(w, r, "AdminDisableTotp", err)
+		return
+	}
+
+	a, _ := domain.AdminFromContext(r.Context())
+
+	err := h.deps.Admins.DisableTOTP(r.Context(), a.ID, deref(body.Code), deref(body.RecoveryCode))
+	if errors.Is(err, domain.ErrUnauthorized) {
+		h.writeServiceError(w, r, domain.ValidationError{}.Add("code", "Неверный код"), "AdminDisableTotp")
+		return
+	}
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminDisableTotp")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) AdminRegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	var body api.TotpCodeRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.writeBadBody(w, r, "AdminRegenerateRecoveryCodes", err)
+		return
+	}
+
+	a, _ := domain.AdminFromContext(r.Context())
+
+	codes, err := h.deps.Admins.RegenerateRecoveryCodes(r.Context(), a.ID, body.Code)
+	if errors.Is(err, domain.ErrUnauthorized) {
+		h.writeServiceError(w, r, domain.ValidationError{}.Add("code", "Неверный код"), "AdminRegenerateRecoveryCodes")
+		return
+	}
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminRegenerateRecoveryCodes")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+func (h *Handler) writeBadBody(w http.ResponseWriter, r *http.Request, op string, err error) {
+	h.deps.Logger.Info("bad request body",
+		"op", op,
+		"request_id", middleware.GetReqID(r.Context()),
+		"err", err,
+	)
+	writeJSON(w, http.StatusBadRequest, api.ErrorResponse{
+		Code:    "bad_request",
+		Message: "Некорректное тело запроса",
+	})
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
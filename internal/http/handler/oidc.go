@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/service/oidclogin"
+)
+
+const (
+	oidcCookieName = "photannie_oidc"
+	oidcCookiePath = "/api/admin/oidc/"
+	oidcCookieTTL  = 600 // секунд на вход у провайдера
+)
+
+// oidcFlowCookie — то, что нужно пережить редирект к провайдеру и обратно.
+type oidcFlowCookie struct {
+	State    string `json:"s"`
+	Nonce    string `json:"n"`
+	Verifier string `json:"v"`
+}
+
+func (h *Handler) AdminOidcLogin(w http.ResponseWriter, r *http.Request) {
+	if h.deps.OIDC == nil {
+		writeOIDCDisabled(w)
+		return
+	}
+
+	f, err := h.deps.OIDC.Begin(r.Context())
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminOidcLogin")
+		return
+	}
+
+	raw, err := json.Marshal(oidcFlowCookie{State: f.State, Nonce: f.Nonce, Verifier: f.Verifier})
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminOidcLogin")
+		return
+	}
+
+	// Lax, а не Strict: возврат от провайдера — межсайтовая навигация, Strict-cookie в ней не придёт.
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(raw),
+		Path:     oidcCookiePath,
+		MaxAge:   oidcCookieTTL,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   h.deps.SecureCookie,
+	})
+	http.Redirect(w, r, f.URL, http.StatusFound)
+}
+
+func (h *Handler) AdminOidcCallback(w http.ResponseWriter, r *http.Request, params api.AdminOidcCallbackParams) {
+	if h.deps.OIDC == nil {
+		writeOIDCDisabled(w)
+		return
+	}
+
+	flow, ok := readOIDCFlowCookie(r)
+	// Cookie одноразовая: повторить callback с тем же state нельзя.
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    "",
+		Path:     oidcCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   h.deps.SecureCookie,
+	})
+
+	if params.Error != nil {
+		h.deps.Logger.Info("oidc provider returned error",
+			"request_id", middleware.GetReqID(r.Context()),
+			"error", *params.Error,
+		)
+		writeJSON(w, http.StatusUnauthorized, api.ErrorResponse{
+			Code:    "admin_unauthorized",
+			Message: "Вход через провайдера отменён",
+		})
+		return
+	}
+
+	state := deref(params.State)
+	if !ok || state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(flow.State)) != 1 || deref(params.Code) == "" {
+		h.deps.Logger.Info("oidc callback rejected",
+			"request_id", middleware.GetReqID(r.Context()),
+			"has_cookie", ok,
+		)
+		writeJSON(w, http.StatusBadRequest, api.ErrorResponse{
+			Code:    "bad_request",
+			Message: "Попытка входа устарела, начните заново",
+		})
+		return
+	}
+
+	a, err := h.deps.OIDC.Complete(r.Context(), deref(params.Code), oidclogin.Flow{
+		State:    flow.State,
+		Nonce:    flow.Nonce,
+		Verifier: flow.Verifier,
+	})
+	if errors.Is(err, domain.ErrUnauthorized) {
+		writeJSON(w, http.StatusUnauthorized, api.ErrorResponse{
+			Code:    "admin_unauthorized",
+			Message: "Этой учётной записи вход в админку не разрешён",
+		})
+		return
+	}
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminOidcCallback")
+		return
+	}
+
+	if _, ok := h.startSession(w, r, a, "AdminOidcCallback"); !ok {
+		return
+	}
+	http.Redirect(w, r, h.deps.OIDCPostLoginURL, http.StatusFound)
+}
+
+func readOIDCFlowCookie(r *http.Request) (oidcFlowCookie, bool) {
+	c, err := r.Cookie(oidcCookieName)
+	if err != nil || c.Value == "" {
+		return oidcFlowCookie{}, false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(c.Value)
+	if err != nil {
+		return oidcFlowCookie{}, false
+	}
+	var f oidcFlowCookie
+	if err := json.Unmarshal(raw, &f); err != nil || f.State == "" || f.Verifier == "" {
+		return oidcFlowCookie{}, false
+	}
+	return f, true
+}
+
+func writeOIDCDisabled(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, api.ErrorResponse{
+		Code:    "oidc_disabled",
+		Message: "Вход через OpenID Connect не настроен",
+	})
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/service/booking"
+)
+
+func (h *Handler) AdminRescheduleBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID, params api.AdminRescheduleBookingParams) {
+	h.withIdempotency(w, r, "AdminRescheduleBooking", params.IdempotencyKey, func(w http.ResponseWriter, r *http.Request) {
+		h.adminRescheduleBooking(w, r, uuid.UUID(bookingId))
+	})
+}
+
+func (h *Handler) adminRescheduleBooking(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	var body api.RescheduleBookingRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.writeBadBody(w, r, "AdminRescheduleBooking", err)
+		return
+	}
+
+	b, err := h.deps.Booking.RescheduleBooking(r.Context(), id, booking.RescheduleInput{
+		Date:          body.Date.Time,
+		StartTimeHHMM: body.StartTime,
+	})
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminRescheduleBooking")
+		return
+	}
+
+	h.writeBookingDetail(w, r, b, "AdminRescheduleBooking")
+}
+
+func (h *Handler) RescheduleClientBooking(w http.ResponseWriter, r *http.Request, bookingId openapi_types.UUID) {
+	var body api.ClientRescheduleBookingRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.writeBadBody(w, r, "RescheduleClientBooking", err)
+		return
+	}
+
+	b, err := h.deps.Booking.RescheduleClientBooking(r.Context(), uuid.UUID(bookingId), body.Token, booking.RescheduleInput{
+		Date:          body.Date.Time,
+		StartTimeHHMM: body.StartTime,
+	})
+	if err != nil {
+		h.writeServiceError(w, r, err, "RescheduleClientBooking")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.toClientBooking(b))
+}
+
+// writeBookingDetail отдаёт запись вместе с историей переносов.
+func (h *Handler) writeBookingDetail(w http.ResponseWriter, r *http.Request, b domain.Booking, op string) {
+	items, err := h.deps.Booking.ListReschedules(r.Context(), b.ID)
+	if err != nil {
+		h.writeServiceError(w, r, err, op)
+		return
+	}
+
+	out := h.toDetail(r.Context(), b)
+	if len(items) > 0 {
+		rs := make([]api.BookingReschedule, 0, len(items))
+		for _, it := range items {
+			rs = append(rs, api.BookingReschedule{
+				OldStartAt:    it.OldStartAt,
+				OldEndAt:      it.OldEndAt,
+				NewStartAt:    it.NewStartAt,
+				NewEndAt:      it.NewEndAt,
+				Source:        api.BookingActor(it.Source),
+				RescheduledBy: it.RescheduledBy,
+				CreatedAt:     it.CreatedAt,
+			})
+		}
+		out.Reschedules = &rs
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/service/catalog"
+)
+
+func (h *Handler) ListPublicServices(w http.ResponseWriter, r *http.Request) {
+	h.listServices(w, r, "ListPublicServices", true)
+}
+
+func (h *Handler) AdminListServices(w http.ResponseWriter, r *http.Request) {
+	h.listServices(w, r, "AdminListServices", false)
+}
+
+func (h *Handler) listServices(w http.ResponseWriter, r *http.Request, op string, activeOnly bool) {
+	items, err := h.deps.Catalog.ListServices(r.Context(), activeOnly)
+	if err != nil {
+		h.writeServiceError(w, r, err, op)
+		return
+	}
+
+	out := make([]api.StudioService, 0, len(items))
+	for _, s := range items {
+		out = append(out, toStudioService(s))
+	}
+
+	writeJSON(w, http.StatusOK, api.StudioServicesResponse{Items: out})
+}
+
+func (h *Handler) AdminCreateService(w http.ResponseWriter, r *http.Request) {
+	in, ok := h.decodeServiceRequest(w, r, "AdminCreateService")
+	if !ok {
+		return
+	}
+
+	created, err := h.deps.Catalog.CreateService(r.Context(), in)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminCreateService")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toStudioService(created))
+}
+
+func (h *Handler) AdminUpdateService(w http.ResponseWriter, r *http.Request, serviceId openapi_types.UUID) {
+	in, ok := h.decodeServiceRequest(w, r, "AdminUpdateService")
+	if !ok {
+		return
+	}
+
+	updated, err := h.deps.Catalog.UpdateService(r.Context(), uuid.UUID(serviceId), in)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminUpdateService")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toStudioService(updated))
+}
+
+func (h *Handler) AdminDeleteService(w http.ResponseWriter, r *http.Request, serviceId openapi_types.UUID) {
+	if err := h.deps.Catalog.DeleteService(r.Context(), uuid.UUID(serviceId)); err != nil {
+		h.writeServiceError(w, r, err, "AdminDeleteService")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) decodeServiceRequest(w http.ResponseWriter, r *http.Request, op string) (catalog.ServiceInput, bool) {
+	var body api.StudioServiceRequest
+	if err := decodeJSON(r, &body); err != nil {
+		h.deps.Logger.Info("bad request body",
+			"op", op,
+			"request_id", middleware.GetReqID(r.Context()),
+			"err", err,
+		)
+		writeJSON(w, http.StatusBadRequest, api.ErrorResponse{
+			Code:    "bad_request",
+			Message: "Некорректное тело запроса",
+		})
+		return catalog.ServiceInput{}, false
+	}
+
+	isActive := true
+	if body.IsActive != nil {
+		isActive = *body.IsActive
+	}
+
+	return catalog.ServiceInput{
+		Name:             body.Name,
+		Description:      body.Description,
+		DurationMinutes:  body.DurationMinutes,
+		PriceRub:         body.PriceRub,
+		IsActive:         isActive,
+		RequiresApproval: body.RequiresApproval,
+	}, true
+}
+
+func toStudioService(s domain.StudioService) api.StudioService {
+	return api.StudioService{
+		Id:               openapi_types.UUID(s.ID),
+		Name:             s.Name,
+		Description:      s.Description,
+		DurationMinutes:  s.DurationMinutes,
+		PriceRub:         s.PriceRub,
+		IsActive:         s.IsActive,
+		RequiresApproval: s.RequiresApproval,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+	}
+}
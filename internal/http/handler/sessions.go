@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"photannie/internal/api"
+	"photannie/internal/domain"
+	"photannie/internal/http/mw"
+)
+
+func (h *Handler) AdminSessionLogout(w http.ResponseWriter, r *http.Request) {
+	if sess, ok := mw.AdminSessionFromContext(r.Context()); ok {
+		if err := h.deps.Sessions.Delete(r.Context(), sess.Token); err != nil {
+			h.writeServiceError(w, r, err, "AdminSessionLogout")
+			return
+		}
+	}
+
+	h.clearSessionCookie(w)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) AdminGetMe(w http.ResponseWriter, r *http.Request) {
+	a, _ := domain.AdminFromContext(r.Context())
+
+	perms := a.Permissions()
+	out := api.AdminMeResponse{
+		Username:    a.Username,
+		Role:        api.AdminMeResponseRole(a.Role),
+		Permissions: make([]string, 0, len(perms)),
+	}
+	for _, p := range perms {
+		out.Permissions = append(out.Permissions, string(p))
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) AdminListSessions(w http.ResponseWriter, r *http.Request) {
+	items, err := h.deps.Sessions.List(r.Context())
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminListSessions")
+		return
+	}
+
+	current, _ := mw.AdminSessionFromContext(r.Context())
+
+	out := make([]api.AdminSession, 0, len(items))
+	for _, s := range items {
+		out = append(out, toAdminSession(s, s.ID == current.ID))
+	}
+
+	writeJSON(w, http.StatusOK, api.AdminSessionsResponse{Items: out})
+}
+
+func (h *Handler) AdminRevokeSession(w http.ResponseWriter, r *http.Request, sessionId openapi_types.UUID) {
+	id := uuid.UUID(sessionId)
+	if err := h.deps.Sessions.Revoke(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "AdminRevokeSession")
+		return
+	}
+
+	h.deps.Logger.Info("admin session revoked",
+		"request_id", middleware.GetReqID(r.Context()),
+		"session_id", id.String(),
+	)
+
+	if current, ok := mw.AdminSessionFromContext(r.Context()); ok && current.ID == id {
+		h.clearSessionCookie(w)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) AdminListLoginLockouts(w http.ResponseWriter, r *http.Request, params api.AdminListLoginLockoutsParams) {
+	limit := 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	items, err := h.deps.LoginGuard.ListLockouts(r.Context(), limit)
+	if err != nil {
+		h.writeServiceError(w, r, err, "AdminListLoginLockouts")
+		return
+	}
+
+	now := time.Now()
+	out := make([]api.LoginLockout, 0, len(items))
+	for _, l := range items {
+		out = append(out, api.LoginLockout{
+			Id:          openapi_types.UUID(l.ID),
+			Scope:       api.LoginLockoutScope(l.Scope),
+			Key:         l.Key,
+			Failures:    l.Failures,
+			LockedUntil: l.LockedUntil,
+			Active:      l.LockedUntil.After(now),
+			CreatedAt:   l.CreatedAt,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, api.AdminLoginLockoutsResponse{Items: out})
+}
+
+func (h *Handler) setSessionCookie(w http.ResponseWriter, sess domain.AdminSession) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     h.deps.CookieName,
+		Value:    sess.Token,
+		Path:     "/",
+		Expires:  sess.ExpiresAt,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Secure:   h.deps.SecureCookie,
+	})
+	// Токен для изменяющих запросов; дальше его отдаёт CSRFGuard на каждый ответ.
+	w.Header().Set(mw.CSRFHeader, mw.CSRFToken(sess.Token))
+}
+
+func (h *Handler) clearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     h.deps.CookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Secure:   h.deps.SecureCookie,
+	})
+}
+
+// clientIP — адрес клиента; RemoteAddr уже подменён mw.RealIP, если запрос пришёл через доверенный прокси.
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+func toAdminSession(s domain.AdminSession, current bool) api.AdminSession {
+	return api.AdminSession{
+		Id:         openapi_types.UUID(s.ID),
+		Username:   s.AdminUsername,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+		Ip:         s.IP,
+		UserAgent:  s.UserAgent,
+		Current:    current,
+	}
+}
@@ -1,21 +1,39 @@
 package mw
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"strings"
+
+	"photannie/internal/domain"
 )
 
 type SessionStore interface {
-	Exists(sessionID string) bool
+	// Touch возвращает ok=false для неизвестной или истёкшей сессии.
+	Touch(ctx context.Context, sessionID string) (domain.AdminSession, bool, error)
+}
+
+// APIKeyAuthenticator проверяет ключ из Authorization: Bearer; ok=false — ключ недействителен.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (domain.Admin, bool, error)
 }
 
 type AdminSessionGuardConfig struct {
 	CookieName string
 	Store      SessionStore
+	APIKeys    APIKeyAuthenticator // nil — вход по API-ключам выключен
 	Logger     *slog.Logger
 }
 
+type adminSessionCtxKey struct{}
+
+// AdminSessionFromContext — сессия, проверенная AdminSessionGuard для текущего запроса.
+func AdminSessionFromContext(ctx context.Context) (domain.AdminSession, bool) {
+	s, ok := ctx.Value(adminSessionCtxKey{}).(domain.AdminSession)
+	return s, ok
+}
+
 func AdminSessionGuard(cfg AdminSessionGuardConfig) func(http.Handler) http.Handler {
 	if cfg.Logger == nil {
 		cfg.Logger = slog.Default()
@@ -28,13 +46,40 @@ func AdminSessionGuard(cfg AdminSessionGuardConfig) func(http.Handler) http.Hand
 				next.ServeHTTP(w, r)
 				return
 			}
-			if path == "/api/admin/session/login" {
+			switch path {
+			case "/api/admin/session/login", "/api/admin/oidc/login", "/api/admin/oidc/callback":
 				next.ServeHTTP(w, r)
 				return
 			}
 
+			// API-ключ заменяет cookie целиком: сессии нет, CSRF не нужен (браузер сам заголовок не подставит).
+			if key, ok := bearerToken(r); ok {
+				a, ok, err := authenticateAPIKey(r.Context(), cfg.APIKeys, key)
+				if err != nil {
+					cfg.Logger.Error("api key lookup failed",
+						"path", r.URL.Path,
+						"method", r.Method,
+						"err", err,
+					)
+					writeInternalError(w)
+					return
+				}
+				if !ok {
+					cfg.Logger.Info("admin unauthorized",
+						"path", r.URL.Path,
+						"method", r.Method,
+						"auth", "api_key",
+					)
+					writeUnauthorized(w)
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(domain.ContextWithAdmin(r.Context(), a)))
+				return
+			}
+
 			c, err := r.Cookie(cfg.CookieName)
-			if err != nil || c == nil || c.Value == "" || cfg.Store == nil || !cfg.Store.Exists(c.Value) {
+			if err != nil || c == nil || c.Value == "" || cfg.Store == nil {
 				cfg.Logger.Info("admin unauthorized",
 					"path", r.URL.Path,
 					"method", r.Method,
@@ -43,13 +88,74 @@ func AdminSessionGuard(cfg AdminSessionGuardConfig) func(http.Handler) http.Hand
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			sess, ok, err := cfg.Store.Touch(r.Context(), c.Value)
+			if err != nil {
+				cfg.Logger.Error("admin session lookup failed",
+					"path", r.URL.Path,
+					"method", r.Method,
+					"err", err,
+				)
+				writeInternalError(w)
+				return
+			}
+			if !ok {
+				cfg.Logger.Info("admin unauthorized",
+					"path", r.URL.Path,
+					"method", r.Method,
+				)
+				writeUnauthorized(w)
+				return
+			}
+			// До второго фактора доступны только шаг MFA и выход.
+			if sess.MFAPending && path != "/api/admin/session/mfa" && path != "/api/admin/session/logout" {
+				cfg.Logger.Info("admin mfa required",
+					"path", r.URL.Path,
+					"method", r.Method,
+				)
+				// После входа через OIDC ответ с токеном был редиректом, и браузер его не отдал странице.
+				w.Header().Set(CSRFHeader, CSRFToken(sess.Token))
+				writeMFARequired(w)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), adminSessionCtxKey{}, sess)
+			ctx = domain.ContextWithAdmin(ctx, domain.Admin{ID: sess.AdminID, Username: sess.AdminUsername, Role: sess.AdminRole})
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+func bearerToken(r *http.Request) (string, bool) {
+	h := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(h, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func authenticateAPIKey(ctx context.Context, keys APIKeyAuthenticator, key string) (domain.Admin, bool, error) {
+	if keys == nil {
+		return domain.Admin{}, false, nil
+	}
+	return keys.Authenticate(ctx, key)
+}
+
 func writeUnauthorized(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusUnauthorized)
 	_, _ = w.Write([]byte(`{"code":"admin_unauthorized","message":"Требуется вход администратора"}`))
 }
+
+func writeMFARequired(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusUnauthorized)
+	_, _ = w.Write([]byte(`{"code":"mfa_required","message":"Подтвердите вход кодом из приложения"}`))
+}
+
+func writeInternalError(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = w.Write([]byte(`{"code":"internal_error","message":"Ошибка сервера"}`))
+}
@@ -0,0 +1,73 @@
+package mw
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const CSRFHeader = "X-CSRF-Token"
+
+// CSRFToken выводит CSRF-токен из токена сессии: хранить его отдельно не нужно,
+// а получить его без самой сессии (HttpOnly cookie) нельзя.
+func CSRFToken(sessionToken string) string {
+	sum := sha256.Sum256([]byte("csrf:" + sessionToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+type CSRFGuardConfig struct {
+	Logger *slog.Logger
+}
+
+// CSRFGuard ставится после AdminSessionGuard: отдаёт токен в заголовке X-CSRF-Token
+// на каждый запрос с сессией и требует его во всех изменяющих запросах к /api/admin/*.
+func CSRFGuard(cfg CSRFGuardConfig) func(http.Handler) http.Handler {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/admin/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			sess, ok := AdminSessionFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			want := CSRFToken(sess.Token)
+			w.Header().Set(CSRFHeader, want)
+
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			got := r.Header.Get(CSRFHeader)
+			if got == "" || subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+				cfg.Logger.Info("csrf token mismatch",
+					"path", r.URL.Path,
+					"method", r.Method,
+					"has_token", got != "",
+				)
+				writeCSRFForbidden(w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeCSRFForbidden(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusForbidden)
+	_, _ = w.Write([]byte(`{"code":"csrf_invalid","message":"Недействительный CSRF-токен, обновите страницу"}`))
+}
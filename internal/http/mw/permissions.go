@@ -0,0 +1,141 @@
+package mw
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"photannie/internal/domain"
+)
+
+// selfService — операции над собственной учёткой и сессией, доступные любой роли.
+const selfService domain.Permission = ""
+
+// sessionOnlyRoutes — операции, недоступные по API-ключу: вход, второй фактор и управление ключами.
+var sessionOnlyRoutes = map[string]bool{
+	"GET /api/admin/oidc/login":           true,
+	"GET /api/admin/oidc/callback":        true,
+	"POST /api/admin/session/logout":      true,
+	"POST /api/admin/session/mfa":         true,
+	"POST /api/admin/mfa/totp":            true,
+	"POST /api/admin/mfa/totp/confirm":    true,
+	"POST /api/admin/mfa/totp/disable":    true,
+	"POST /api/admin/mfa/recovery-codes":  true,
+	"GET /api/admin/api-keys":             true,
+	"POST /api/admin/api-keys":            true,
+	"DELETE /api/admin/api-keys/{key_id}": true,
+}
+
+// adminRoutePermissions — право, нужное для операции "<METHOD> <шаблон пути>".
+// Админский маршрут, которого нет в таблице, доступен только владельцу.
+var adminRoutePermissions = map[string]domain.Permission{
+	"POST /api/admin/session/login":           selfService,
+	"GET /api/admin/oidc/login":               selfService,
+	"GET /api/admin/oidc/callback":            selfService,
+	"POST /api/admin/session/logout":          selfService,
+	"POST /api/admin/session/mfa":             selfService,
+	"GET /api/admin/me":                       selfService,
+	"GET /api/admin/mfa":                      selfService,
+	"POST /api/admin/mfa/totp":                selfService,
+	"POST /api/admin/mfa/totp/confirm":        selfService,
+	"POST /api/admin/mfa/totp/disable":        selfService,
+	"POST /api/admin/mfa/recovery-codes":      selfService,
+	"GET /api/admin/sessions":                 domain.PermAdminsManage,
+	"DELETE /api/admin/sessions/{session_id}": domain.PermAdminsManage,
+	"GET /api/admin/login-lockouts":           domain.PermAdminsManage,
+	"GET /api/admin/api-keys":                 domain.PermAdminsManage,
+	"POST /api/admin/api-keys":                domain.PermAdminsManage,
+	"DELETE /api/admin/api-keys/{key_id}":     domain.PermAdminsManage,
+
+	"GET /api/admin/bookings":                               domain.PermScheduleRead,
+	"GET /api/admin/bookings/{booking_id}":                  domain.PermScheduleRead,
+	"POST /api/admin/bookings/{booking_id}/cancel":          domain.PermScheduleWrite,
+	"POST /api/admin/bookings/{booking_id}/confirm":         domain.PermScheduleWrite,
+	"POST /api/admin/bookings/{booking_id}/complete":        domain.PermScheduleWrite,
+	"POST /api/admin/bookings/{booking_id}/no-show":         domain.PermScheduleWrite,
+	"POST /api/admin/bookings/{booking_id}/reschedule":      domain.PermScheduleWrite,
+	"GET /api/admin/booking-requests":                       domain.PermScheduleRead,
+	"POST /api/admin/booking-requests/{booking_id}/approve": domain.PermScheduleWrite,
+	"POST /api/admin/booking-requests/{booking_id}/reject":  domain.PermScheduleWrite,
+	"GET /api/admin/blocks":                                 domain.PermScheduleRead,
+	"POST /api/admin/blocks":                                domain.PermScheduleWrite,
+	"DELETE /api/admin/blocks/{block_id}":                   domain.PermScheduleWrite,
+	"GET /api/admin/closures":                               domain.PermScheduleRead,
+	"POST /api/admin/closures":                              domain.PermScheduleWrite,
+	"DELETE /api/admin/closures/{closure_id}":               domain.PermScheduleWrite,
+	"GET /api/admin/services":                               domain.PermScheduleRead,
+	"POST /api/admin/services":                              domain.PermScheduleWrite,
+	"PUT /api/admin/services/{service_id}":                  domain.PermScheduleWrite,
+	"DELETE /api/admin/services/{service_id}":               domain.PermScheduleWrite,
+	"GET /api/admin/clients":                                domain.PermClientPII,
+	"GET /api/admin/clients/{client_id}":                    domain.PermClientPII,
+	"POST /api/admin/clients/{client_id}/merge":             domain.PermClientPII,
+}
+
+type AdminPermissionsConfig struct {
+	BaseURL string
+	Logger  *slog.Logger
+}
+
+// AdminPermissions проверяет роль администратора по таблице прав. Подключается как
+// middleware сгенерированных обработчиков (api.ChiServerOptions.Middlewares), где шаблон
+// маршрута уже известен; сессию к этому моменту проверил AdminSessionGuard.
+func AdminPermissions(cfg AdminPermissionsConfig) func(http.Handler) http.Handler {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pattern := strings.TrimPrefix(chi.RouteContext(r.Context()).RoutePattern(), cfg.BaseURL)
+			if !strings.HasPrefix(pattern, "/api/admin/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route := r.Method + " " + pattern
+			perm, known := adminRoutePermissions[route]
+			if !known {
+				perm = domain.PermAdminsManage
+			}
+
+			a, _ := domain.AdminFromContext(r.Context())
+			if a.APIKeyID != nil && sessionOnlyRoutes[route] {
+				cfg.Logger.Info("admin forbidden",
+					"path", r.URL.Path,
+					"method", r.Method,
+					"api_key_id", a.APIKeyID.String(),
+					"reason", "session_only",
+				)
+				writeForbidden(w)
+				return
+			}
+			if perm == selfService {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !a.Can(perm) {
+				cfg.Logger.Info("admin forbidden",
+					"path", r.URL.Path,
+					"method", r.Method,
+					"username", a.Username,
+					"role", string(a.Role),
+					"permission", string(perm),
+				)
+				writeForbidden(w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeForbidden(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusForbidden)
+	_, _ = w.Write([]byte(`{"code":"forbidden","message":"Недостаточно прав"}`))
+}
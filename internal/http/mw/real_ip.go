@@ -0,0 +1,74 @@
+package mw
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// RealIP подменяет RemoteAddr адресом клиента из X-Forwarded-For или X-Real-IP, но только если
+// соединение пришло от доверенного прокси: иначе заголовок подставит кто угодно и обойдёт ограничения по IP.
+// X-Forwarded-For разбирается справа налево до первого адреса, не принадлежащего доверенным прокси.
+func RealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(trusted) > 0 {
+				if peer, ok := parseRemoteAddr(r.RemoteAddr); ok && isTrusted(trusted, peer) {
+					if ip, ok := forwardedFor(r, trusted); ok {
+						r.RemoteAddr = ip.String()
+					}
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func forwardedFor(r *http.Request, trusted []netip.Prefix) (netip.Addr, bool) {
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		var last netip.Addr
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break
+			}
+			ip = ip.Unmap()
+			if !isTrusted(trusted, ip) {
+				return ip, true
+			}
+			last = ip
+		}
+		// Вся цепочка из доверенных прокси — клиентом считается самый левый разобранный адрес.
+		return last, last.IsValid()
+	}
+
+	if v := strings.TrimSpace(r.Header.Get("X-Real-IP")); v != "" {
+		if ip, err := netip.ParseAddr(v); err == nil {
+			return ip.Unmap(), true
+		}
+	}
+	return netip.Addr{}, false
+}
+
+func parseRemoteAddr(addr string) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
+
+func isTrusted(trusted []netip.Prefix, ip netip.Addr) bool {
+	for _, p := range trusted {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
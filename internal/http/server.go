@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
 	"photannie/internal/observability/metrics"
@@ -20,10 +21,6 @@ import (
 	appmw "photannie/internal/http/mw"
 )
 
-type SessionStore interface {
-	Exists(sessionID string) bool
-}
-
 type Server struct {
 	httpServer *http.Server
 	log        *slog.Logger
@@ -40,12 +37,16 @@ type Config struct {
 	ShutdownTimeout time.Duration
 
 	AllowedOrigins []string
+	TrustedProxies []netip.Prefix
 
 	SessionCookieName string
 	SecureCookie      bool
+	APIKeys           appmw.APIKeyAuthenticator
+
+	Metrics *metrics.Metrics // nil — создаётся свой реестр
 }
 
-func New(cfg Config, h *apphandler.Handler, sessions SessionStore, log *slog.Logger) *Server {
+func New(cfg Config, h *apphandler.Handler, sessions appmw.SessionStore, log *slog.Logger) *Server {
 	if log == nil {
 		log = slog.Default()
 	}
@@ -62,7 +63,7 @@ func New(cfg Config, h *apphandler.Handler, sessions SessionStore, log *slog.Log
 	r := chi.NewRouter()
 
 	r.Use(chimw.RequestID)
-	r.Use(chimw.RealIP)
+	r.Use(appmw.RealIP(cfg.TrustedProxies))
 	r.Use(chimw.Recoverer)
 	if cfg.RequestTimeout > 0 {
 		r.Use(chimw.Timeout(cfg.RequestTimeout))
@@ -72,9 +73,9 @@ func New(cfg Config, h *apphandler.Handler, sessions SessionStore, log *slog.Log
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   cfg.AllowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Content-Type", "Idempotency-Key"},
-		ExposedHeaders:   []string{"X-Request-Id"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Content-Type", "Idempotency-Key", appmw.CSRFHeader},
+		ExposedHeaders:   []string{"X-Request-Id", "Idempotent-Replayed", appmw.CSRFHeader},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -82,17 +83,26 @@ func New(cfg Config, h *apphandler.Handler, sessions SessionStore, log *slog.Log
 	r.Use(appmw.AdminSessionGuard(appmw.AdminSessionGuardConfig{
 		CookieName: cfg.SessionCookieName,
 		Store:      sessions,
+		APIKeys:    cfg.APIKeys,
 		Logger:     log,
 	}))
-	m := metrics.New("photannie")
+	r.Use(appmw.CSRFGuard(appmw.CSRFGuardConfig{Logger: log}))
+
+	m := cfg.Metrics
+	if m == nil {
+		m = metrics.New("photannie")
+	}
 
 	r.Use(m.Middleware)
 
 	r.Handle("/metrics", m.Handler())
 
 	api.HandlerWithOptions(h, api.ChiServerOptions{
-		BaseURL:          cfg.BaseURL,
-		BaseRouter:       r,
+		BaseURL:    cfg.BaseURL,
+		BaseRouter: r,
+		Middlewares: []api.MiddlewareFunc{
+			appmw.AdminPermissions(appmw.AdminPermissionsConfig{BaseURL: cfg.BaseURL, Logger: log}),
+		},
 		ErrorHandlerFunc: apphandler.OapiBindingErrorHandler(log),
 	})
 
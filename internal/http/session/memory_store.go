@@ -1,37 +1,199 @@
 package session
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
+	"sort"
 	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
 )
 
+// AdminGetter — откуда MemoryStore берёт текущие данные администратора (repository.AdminRepository).
+type AdminGetter interface {
+	GetByID(ctx context.Context, id uuid.UUID) (domain.Admin, error)
+}
+
 type MemoryStore struct {
+	admins AdminGetter
+	opts   Options
+	now    func() time.Time
+
 	mu sync.RWMutex
-	m  map[string]struct{}
+	m  map[string]memorySession // token -> session
 }
 
-func NewMemoryStore() *MemoryStore {
-	return &MemoryStore{m: make(map[string]struct{}, 16)}
+// memorySession — сессия и хэш пароля на момент входа: после смены пароля сессия больше не принимается.
+type memorySession struct {
+	domain.AdminSession
+	passwordHash string
 }
 
-func (s *MemoryStore) New() (string, error) {
-	b := make([]byte, 32)
-	if _, err := rand.Read(b); err != nil {
-		return "", err
+func NewMemoryStore(admins AdminGetter, opts Options) *MemoryStore {
+	return &MemoryStore{
+		admins: admins,
+		opts:   opts,
+		now:    time.Now,
+		m:      make(map[string]memorySession, 16),
+	}
+}
+
+func (s *MemoryStore) New(_ context.Context, p NewParams) (domain.AdminSession, error) {
+	token, err := newToken()
+	if err != nil {
+		return domain.AdminSession{}, err
+	}
+
+	now := s.now().UTC()
+	sess := domain.AdminSession{
+		ID:            uuid.New(),
+		Token:         token,
+		AdminID:       p.Admin.ID,
+		AdminUsername: p.Admin.Username,
+		AdminRole:     p.Admin.Role,
+		CreatedAt:     now,
+		LastSeenAt:    now,
+		ExpiresAt:     now.Add(s.opts.initialTTL(p.MFAPending)),
+		IP:            p.IP,
+		UserAgent:     p.UserAgent,
+		MFAPending:    p.MFAPending,
 	}
-	id := base64.RawURLEncoding.EncodeToString(b)
 
 	s.mu.Lock()
-	s.m[id] = struct{}{}
+	s.m[token] = memorySession{AdminSession: sess, passwordHash: p.Admin.PasswordHash}
 	s.mu.Unlock()
 
-	return id, nil
+	return sess, nil
 }
 
-func (s *MemoryStore) Exists(sessionID string) bool {
+// Touch проверяет сессию и продлевает её по простою; просроченная сессия удаляется.
+// Роль и пароль сверяются с admins на каждый запрос, как в PostgresStore, поэтому смена роли действует сразу,
+// а смена пароля завершает сессию.
+func (s *MemoryStore) Touch(ctx context.Context, token string) (domain.AdminSession, bool, error) {
+	now := s.now().UTC()
+
+	s.mu.Lock()
+	sess, ok := s.m[token]
+	if ok && s.expired(sess.AdminSession, now) {
+		delete(s.m, token)
+		ok = false
+	}
+	s.mu.Unlock()
+	if !ok {
+		return domain.AdminSession{}, false, nil
+	}
+
+	a, err := s.admins.GetByID(ctx, sess.AdminID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return domain.AdminSession{}, false, err
+	}
+	if err != nil || a.PasswordHash != sess.passwordHash {
+		_ = s.Delete(ctx, token)
+		return domain.AdminSession{}, false, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Пока читали admins, сессию могли отозвать или завершить её MFA.
+	sess, ok = s.m[token]
+	if !ok {
+		return domain.AdminSession{}, false, nil
+	}
+	sess.AdminUsername = a.Username
+	sess.AdminRole = a.Role
+	sess.LastSeenAt = now
+	s.m[token] = sess
+	return sess.AdminSession, true, nil
+}
+
+func (s *MemoryStore) CompleteMFA(_ context.Context, token string) (domain.AdminSession, error) {
+	now := s.now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.m[token]
+	if !ok || !sess.MFAPending || s.expired(sess.AdminSession, now) {
+		return domain.AdminSession{}, domain.ErrNotFound
+	}
+
+	sess.MFAPending = false
+	sess.LastSeenAt = now
+	sess.ExpiresAt = now.Add(s.opts.TTL)
+	s.m[token] = sess
+	return sess.AdminSession, nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, token string) error {
+	s.mu.Lock()
+	delete(s.m, token)
+	s.mu.Unlock()
+	return nil
+}
+
+// List возвращает действующие сессии, новые первыми.
+func (s *MemoryStore) List(_ context.Context) ([]domain.AdminSession, error) {
+	now := s.now().UTC()
+
 	s.mu.RLock()
-	_, ok := s.m[sessionID]
+	out := make([]domain.AdminSession, 0, len(s.m))
+	for _, sess := range s.m {
+		if !s.expired(sess.AdminSession, now) {
+			out = append(out, sess.AdminSession)
+		}
+	}
 	s.mu.RUnlock()
-	return ok
+
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (s *MemoryStore) Revoke(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for token, sess := range s.m {
+		if sess.ID == id {
+			delete(s.m, token)
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (s *MemoryStore) DeleteExpired(_ context.Context) (int64, error) {
+	now := s.now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	for token, sess := range s.m {
+		if s.expired(sess.AdminSession, now) {
+			delete(s.m, token)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *MemoryStore) expired(sess domain.AdminSession, now time.Time) bool {
+	if !now.Before(sess.ExpiresAt) {
+		return true
+	}
+	return s.opts.IdleTimeout > 0 && !now.Before(sess.LastSeenAt.Add(s.opts.IdleTimeout))
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
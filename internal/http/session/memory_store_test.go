@@ -0,0 +1,86 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+)
+
+type fakeAdmins map[uuid.UUID]domain.Admin
+
+func (f fakeAdmins) GetByID(_ context.Context, id uuid.UUID) (domain.Admin, error) {
+	a, ok := f[id]
+	if !ok {
+		return domain.Admin{}, domain.ErrNotFound
+	}
+	return a, nil
+}
+
+func TestMemoryStoreTouchReadsCurrentRole(t *testing.T) {
+	ctx := context.Background()
+	a := domain.Admin{ID: uuid.New(), Username: "anna", Role: domain.AdminRoleOwner}
+	admins := fakeAdmins{a.ID: a}
+	s := NewMemoryStore(admins, Options{TTL: time.Hour})
+
+	sess, err := s.New(ctx, NewParams{Admin: a})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.Role = domain.AdminRoleViewer
+	admins[a.ID] = a
+
+	got, ok, err := s.Touch(ctx, sess.Token)
+	if err != nil || !ok {
+		t.Fatalf("Touch: ok = %v, err = %v", ok, err)
+	}
+	if got.AdminRole != domain.AdminRoleViewer {
+		t.Errorf("role = %s, want %s", got.AdminRole, domain.AdminRoleViewer)
+	}
+}
+
+func TestMemoryStoreTouchDeletedAdmin(t *testing.T) {
+	ctx := context.Background()
+	a := domain.Admin{ID: uuid.New(), Username: "anna", Role: domain.AdminRoleOwner}
+	admins := fakeAdmins{a.ID: a}
+	s := NewMemoryStore(admins, Options{TTL: time.Hour})
+
+	sess, err := s.New(ctx, NewParams{Admin: a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(admins, a.ID)
+
+	if _, ok, err := s.Touch(ctx, sess.Token); err != nil || ok {
+		t.Fatalf("Touch: ok = %v, err = %v, want session gone", ok, err)
+	}
+	if list, _ := s.List(ctx); len(list) != 0 {
+		t.Errorf("List = %d sessions, want 0", len(list))
+	}
+}
+
+func TestMemoryStoreTouchAfterPasswordChange(t *testing.T) {
+	ctx := context.Background()
+	a := domain.Admin{ID: uuid.New(), Username: "anna", Role: domain.AdminRoleOwner, PasswordHash: "old"}
+	admins := fakeAdmins{a.ID: a}
+	s := NewMemoryStore(admins, Options{TTL: time.Hour})
+
+	sess, err := s.New(ctx, NewParams{Admin: a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := s.Touch(ctx, sess.Token); err != nil || !ok {
+		t.Fatalf("Touch before change: ok = %v, err = %v", ok, err)
+	}
+
+	a.PasswordHash = "new"
+	admins[a.ID] = a
+
+	if _, ok, err := s.Touch(ctx, sess.Token); err != nil || ok {
+		t.Fatalf("Touch after change: ok = %v, err = %v, want session gone", ok, err)
+	}
+}
@@ -0,0 +1,108 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+// PostgresStore хранит сессии в таблице sessions, поэтому они переживают рестарт
+// и общие для нескольких реплик. В БД пишется только хэш токена.
+type PostgresStore struct {
+	repo repository.AdminSessionRepository
+	opts Options
+	now  func() time.Time
+}
+
+func NewPostgresStore(repo repository.AdminSessionRepository, opts Options) *PostgresStore {
+	return &PostgresStore{repo: repo, opts: opts, now: time.Now}
+}
+
+func (s *PostgresStore) New(ctx context.Context, p NewParams) (domain.AdminSession, error) {
+	token, err := newToken()
+	if err != nil {
+		return domain.AdminSession{}, err
+	}
+
+	now := s.now().UTC()
+	sess, err := s.repo.Create(ctx, repository.CreateAdminSessionParams{
+		TokenHash:  hashToken(token),
+		AdminID:    p.Admin.ID,
+		NowUTC:     now,
+		ExpiresAt:  now.Add(s.opts.initialTTL(p.MFAPending)),
+		IP:         p.IP,
+		UserAgent:  p.UserAgent,
+		MFAPending: p.MFAPending,
+	})
+	if err != nil {
+		return domain.AdminSession{}, err
+	}
+
+	sess.Token = token
+	return sess, nil
+}
+
+func (s *PostgresStore) Touch(ctx context.Context, token string) (domain.AdminSession, bool, error) {
+	now := s.now().UTC()
+
+	sess, err := s.repo.Touch(ctx, hashToken(token), now, s.idleSince(now))
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.AdminSession{}, false, nil
+	}
+	if err != nil {
+		return domain.AdminSession{}, false, err
+	}
+
+	sess.Token = token
+	return sess, true, nil
+}
+
+func (s *PostgresStore) CompleteMFA(ctx context.Context, token string) (domain.AdminSession, error) {
+	now := s.now().UTC()
+
+	sess, err := s.repo.CompleteMFA(ctx, hashToken(token), now, now.Add(s.opts.TTL))
+	if err != nil {
+		return domain.AdminSession{}, err
+	}
+
+	sess.Token = token
+	return sess, nil
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, token string) error {
+	return s.repo.DeleteByTokenHash(ctx, hashToken(token))
+}
+
+func (s *PostgresStore) List(ctx context.Context) ([]domain.AdminSession, error) {
+	now := s.now().UTC()
+	return s.repo.ListActive(ctx, now, s.idleSince(now))
+}
+
+func (s *PostgresStore) Revoke(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *PostgresStore) DeleteExpired(ctx context.Context) (int64, error) {
+	now := s.now().UTC()
+	return s.repo.DeleteExpired(ctx, now, s.idleSince(now))
+}
+
+// idleSince — сессии, не обращавшиеся с этого момента, считаются истёкшими; без IdleTimeout граница не действует.
+func (s *PostgresStore) idleSince(now time.Time) time.Time {
+	if s.opts.IdleTimeout <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-s.opts.IdleTimeout)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+)
+
+type Options struct {
+	TTL         time.Duration // абсолютное время жизни с момента входа
+	IdleTimeout time.Duration // максимальный простой между запросами
+	MFATimeout  time.Duration // сколько живёт сессия, ожидающая второго фактора
+}
+
+type NewParams struct {
+	// Admin из хранилища: MemoryStore запоминает PasswordHash, чтобы после смены пароля сессия не принималась.
+	Admin domain.Admin
+
+	IP        string
+	UserAgent string
+
+	MFAPending bool
+}
+
+// Store — общий интерфейс хранилищ сессий; выбирается через SESSION_STORE.
+type Store interface {
+	New(ctx context.Context, p NewParams) (domain.AdminSession, error)
+	Touch(ctx context.Context, token string) (domain.AdminSession, bool, error)
+	// CompleteMFA переводит сессию из ожидания второго фактора в обычную; для остальных — ErrNotFound.
+	CompleteMFA(ctx context.Context, token string) (domain.AdminSession, error)
+	Delete(ctx context.Context, token string) error
+	List(ctx context.Context) ([]domain.AdminSession, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+var (
+	_ Store = (*MemoryStore)(nil)
+	_ Store = (*PostgresStore)(nil)
+)
+
+func (o Options) initialTTL(mfaPending bool) time.Duration {
+	if mfaPending && o.MFATimeout > 0 {
+		return o.MFATimeout
+	}
+	return o.TTL
+}
@@ -9,6 +9,8 @@ import (
 	chimw "github.com/go-chi/chi/v5/middleware"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"photannie/internal/domain"
 )
 
 type Metrics struct {
@@ -16,6 +18,10 @@ type Metrics struct {
 
 	reqTotal *prometheus.CounterVec
 	reqDur   *prometheus.HistogramVec
+
+	loginFailures prometheus.Counter
+	loginBlocked  prometheus.Counter
+	loginLockouts *prometheus.CounterVec
 }
 
 func New(service string) *Metrics {
@@ -49,15 +55,58 @@ func New(service string) *Metrics {
 		[]string{"method", "route"},
 	)
 
-	reg.MustRegister(reqTotal, reqDur)
+	loginFailures := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   "photannie",
+			Subsystem:   "auth",
+			Name:        "login_failures_total",
+			Help:        "Failed admin login attempts (wrong username or password)",
+			ConstLabels: prometheus.Labels{"service": service},
+		},
+	)
+
+	loginBlocked := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   "photannie",
+			Subsystem:   "auth",
+			Name:        "login_blocked_total",
+			Help:        "Admin login attempts rejected because of an active lockout",
+			ConstLabels: prometheus.Labels{"service": service},
+		},
+	)
+
+	loginLockouts := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "photannie",
+			Subsystem:   "auth",
+			Name:        "login_lockouts_total",
+			Help:        "Temporary admin login lockouts",
+			ConstLabels: prometheus.Labels{"service": service},
+		},
+		[]string{"scope"},
+	)
+
+	reg.MustRegister(reqTotal, reqDur, loginFailures, loginBlocked, loginLockouts)
 
 	return &Metrics{
 		reg:      reg,
 		reqTotal: reqTotal,
 		reqDur:   reqDur,
+
+		loginFailures: loginFailures,
+		loginBlocked:  loginBlocked,
+		loginLockouts: loginLockouts,
 	}
 }
 
+func (m *Metrics) LoginFailed() { m.loginFailures.Inc() }
+
+func (m *Metrics) LoginBlocked() { m.loginBlocked.Inc() }
+
+func (m *Metrics) LoginLocked(scope domain.LoginScope) {
+	m.loginLockouts.WithLabelValues(string(scope)).Inc()
+}
+
 func (m *Metrics) Handler() http.Handler {
 	return promhttp.HandlerFor(m.reg, promhttp.HandlerOpts{
 		EnableOpenMetrics: true,
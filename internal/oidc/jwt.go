@@ -0,0 +1,191 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Issuer          string     `json:"iss"`
+	Subject         string     `json:"sub"`
+	Audience        audience   `json:"aud"`
+	AuthorizedParty string     `json:"azp"`
+	ExpiresAt       int64      `json:"exp"`
+	IssuedAt        int64      `json:"iat"`
+	Nonce           string     `json:"nonce"`
+	Email           string     `json:"email"`
+	EmailVerified   stringBool `json:"email_verified"`
+}
+
+type jwt struct {
+	header       jwtHeader
+	claims       jwtClaims
+	signingInput string
+	signature    []byte
+}
+
+func parseJWT(raw string) (jwt, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return jwt{}, fmt.Errorf("%w: malformed jwt", ErrInvalidToken)
+	}
+
+	var t jwt
+	if err := decodeSegment(parts[0], &t.header); err != nil {
+		return jwt{}, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	if err := decodeSegment(parts[1], &t.claims); err != nil {
+		return jwt{}, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return jwt{}, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	t.signature = sig
+	t.signingInput = parts[0] + "." + parts[1]
+	return t, nil
+}
+
+// verify принимает только асимметричные алгоритмы: none и HS* с ключами из JWKS смысла не имеют.
+func (t jwt) verify(key any) error {
+	digest := sha256.Sum256([]byte(t.signingInput))
+
+	switch t.header.Alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: key type does not match RS256", ErrInvalidToken)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], t.signature); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(t.signature) != 64 {
+			return fmt.Errorf("%w: key type does not match ES256", ErrInvalidToken)
+		}
+		r := new(big.Int).SetBytes(t.signature[:32])
+		s := new(big.Int).SetBytes(t.signature[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, t.header.Alg)
+	}
+	return nil
+}
+
+func decodeSegment(seg string, out any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+// audience — aud бывает и строкой, и массивом.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (a audience) contains(v string) bool {
+	for _, x := range a {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// stringBool — некоторые провайдеры отдают email_verified строкой "true".
+type stringBool bool
+
+func (b *stringBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	default:
+		*b = false
+	}
+	return nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// publicKeys пропускает ключи шифрования и неподдерживаемые типы.
+func (s jwkSet) publicKeys() (map[string]any, error) {
+	out := make(map[string]any, len(s.Keys))
+	for _, k := range s.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		switch k.Kty {
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(k.N)
+			if err != nil {
+				return nil, fmt.Errorf("oidc: jwks: key %q: %w", k.Kid, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(k.E)
+			if err != nil {
+				return nil, fmt.Errorf("oidc: jwks: key %q: %w", k.Kid, err)
+			}
+			out[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "EC":
+			if k.Crv != "P-256" {
+				continue
+			}
+			x, err := base64.RawURLEncoding.DecodeString(k.X)
+			if err != nil {
+				return nil, fmt.Errorf("oidc: jwks: key %q: %w", k.Kid, err)
+			}
+			y, err := base64.RawURLEncoding.DecodeString(k.Y)
+			if err != nil {
+				return nil, fmt.Errorf("oidc: jwks: key %q: %w", k.Kid, err)
+			}
+			pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+			if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+				return nil, fmt.Errorf("oidc: jwks: key %q: point is not on curve", k.Kid)
+			}
+			out[k.Kid] = pub
+		}
+	}
+	return out, nil
+}
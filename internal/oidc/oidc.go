@@ -0,0 +1,343 @@
+// Package oidc — минимальный клиент OpenID Connect для входа администраторов:
+// discovery, authorization code flow с PKCE и проверка ID token по JWKS провайдера.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidToken — ID token не прошёл проверку (подпись, издатель, аудитория, срок, nonce).
+var ErrInvalidToken = errors.New("oidc: invalid id token")
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string // пусто — публичный клиент, только PKCE
+	RedirectURL  string
+
+	Scopes []string // пусто — openid email
+
+	HTTPClient *http.Client
+}
+
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Nonce         string
+	ExpiresAt     time.Time
+	IssuedAt      time.Time
+}
+
+// Provider загружает метаданные провайдера при первом обращении, а не при старте,
+// чтобы недоступный IdP не мешал запуску сервиса и входу по паролю.
+// Сетевые запросы к IdP идут без блокировки mu: медленный провайдер не должен задерживать остальные входы.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	now    func() time.Time
+
+	mu          sync.Mutex
+	meta        *metadata
+	metaExpires time.Time      // после этого момента метаданные перечитываются
+	keys        map[string]any // kid -> *rsa.PublicKey | *ecdsa.PublicKey
+	keysNext    time.Time      // раньше этого момента JWKS повторно не запрашивается
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+const (
+	clockSkew        = time.Minute
+	jwksMinRefresh   = time.Minute
+	metadataTTL      = time.Hour
+	maxResponseBytes = 1 << 20
+)
+
+func NewProvider(cfg Config) (*Provider, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, fmt.Errorf("oidc: issuer, client id and redirect url are required")
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email"}
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Provider{cfg: cfg, client: client, now: time.Now}, nil
+}
+
+// AuthCodeURL — адрес страницы входа провайдера; challenge — S256 от code verifier (см. NewPKCE).
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, challenge string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", challenge)
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange меняет код авторизации на ID token (сырой JWT).
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", verifier)
+	form.Set("client_id", p.cfg.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	var out struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(req, &out)
+	if err != nil {
+		return "", fmt.Errorf("oidc: token endpoint: %w", err)
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("oidc: token endpoint: status %d: %s %s", status, out.Error, out.ErrorDescription)
+	}
+	if out.IDToken == "" {
+		return "", fmt.Errorf("oidc: token endpoint: no id_token in response")
+	}
+	return out.IDToken, nil
+}
+
+// VerifyIDToken проверяет подпись по JWKS, издателя, аудиторию, срок действия и nonce.
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (Claims, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	tok, err := parseJWT(raw)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	key, err := p.key(ctx, meta, tok.header.Kid)
+	if err != nil {
+		return Claims{}, err
+	}
+	if err := tok.verify(key); err != nil {
+		return Claims{}, err
+	}
+
+	c := tok.claims
+	now := p.now()
+	switch {
+	case c.Issuer != meta.Issuer:
+		return Claims{}, fmt.Errorf("%w: issuer %q", ErrInvalidToken, c.Issuer)
+	case !c.Audience.contains(p.cfg.ClientID):
+		return Claims{}, fmt.Errorf("%w: audience", ErrInvalidToken)
+	case len(c.Audience) > 1 && c.AuthorizedParty != p.cfg.ClientID:
+		return Claims{}, fmt.Errorf("%w: azp", ErrInvalidToken)
+	case c.ExpiresAt == 0 || !now.Before(time.Unix(c.ExpiresAt, 0).Add(clockSkew)):
+		return Claims{}, fmt.Errorf("%w: expired", ErrInvalidToken)
+	case c.IssuedAt != 0 && time.Unix(c.IssuedAt, 0).After(now.Add(clockSkew)):
+		return Claims{}, fmt.Errorf("%w: issued in the future", ErrInvalidToken)
+	case c.Nonce != nonce:
+		return Claims{}, fmt.Errorf("%w: nonce", ErrInvalidToken)
+	case c.Subject == "":
+		return Claims{}, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+
+	return Claims{
+		Issuer:        c.Issuer,
+		Subject:       c.Subject,
+		Email:         c.Email,
+		EmailVerified: bool(c.EmailVerified),
+		Nonce:         c.Nonce,
+		ExpiresAt:     time.Unix(c.ExpiresAt, 0).UTC(),
+		IssuedAt:      time.Unix(c.IssuedAt, 0).UTC(),
+	}, nil
+}
+
+// metadata отдаёт закэшированные метаданные, пока не прошёл metadataTTL; если перечитать их
+// не удалось, продолжает работать с прежними.
+func (p *Provider) metadata(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	stale := p.meta
+	fresh := stale != nil && p.now().Before(p.metaExpires)
+	p.mu.Unlock()
+	if fresh {
+		return stale, nil
+	}
+
+	m, err := p.fetchMetadata(ctx)
+	if err != nil {
+		if stale != nil {
+			return stale, nil
+		}
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.meta = m
+	p.metaExpires = p.now().Add(metadataTTL)
+	p.mu.Unlock()
+	return m, nil
+}
+
+func (p *Provider) fetchMetadata(ctx context.Context) (*metadata, error) {
+	u := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var m metadata
+	status, err := p.doJSON(req, &m)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: discovery: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc: discovery: status %d", status)
+	}
+	if m.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc: discovery: issuer %q does not match configured %q", m.Issuer, p.cfg.Issuer)
+	}
+	if m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc: discovery: incomplete provider metadata")
+	}
+
+	return &m, nil
+}
+
+// key ищет ключ по kid; при неизвестном kid JWKS перечитывается (провайдер мог сменить ключи),
+// но не чаще раза в jwksMinRefresh.
+func (p *Provider) key(ctx context.Context, meta *metadata, kid string) (any, error) {
+	p.mu.Lock()
+	if k, ok := p.lookupKey(kid); ok {
+		p.mu.Unlock()
+		return k, nil
+	}
+	now := p.now()
+	if now.Before(p.keysNext) {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%w: unknown key id %q", ErrInvalidToken, kid)
+	}
+	p.keysNext = now.Add(jwksMinRefresh)
+	p.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set jwkSet
+	status, err := p.doJSON(req, &set)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: jwks: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc: jwks: status %d", status)
+	}
+
+	keys, err := set.publicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = keys
+
+	if k, ok := p.lookupKey(kid); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key id %q", ErrInvalidToken, kid)
+}
+
+func (p *Provider) lookupKey(kid string) (any, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, k := range p.keys {
+			return k, true
+		}
+	}
+	k, ok := p.keys[kid]
+	return k, ok
+}
+
+func (p *Provider) doJSON(req *http.Request, out any) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if err := json.Unmarshal(body, out); err != nil && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// NewPKCE возвращает code verifier и его S256 challenge.
+func NewPKCE() (verifier, challenge string, err error) {
+	verifier, err = RandomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// RandomString — n случайных байт в base64url; для state и nonce.
+func RandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,327 @@
+package oidc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"photannie/internal/oidc/oidcstub"
+)
+
+const (
+	testClientID = "photannie"
+	testRedirect = "http://app.test/api/admin/oidc/callback"
+	testEmail    = "anna@example.com"
+	testNonce    = "nonce-1"
+)
+
+type testIdP struct {
+	srv       *httptest.Server
+	stub      *oidcstub.Stub
+	discovery atomic.Int32 // сколько раз запрашивали discovery
+	down      atomic.Bool  // discovery отвечает 503
+}
+
+// newTestIdP поднимает заглушку провайдера на httptest-сервере; issuer — адрес этого сервера.
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+
+	idp := &testIdP{}
+	var h http.Handler
+	idp.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/openid-configuration" {
+			idp.discovery.Add(1)
+			if idp.down.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	}))
+	t.Cleanup(idp.srv.Close)
+
+	stub, err := oidcstub.New(oidcstub.Config{
+		Issuer:   idp.srv.URL,
+		ClientID: testClientID,
+		Email:    testEmail,
+		Logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp.stub = stub
+	h = stub.Handler()
+	return idp
+}
+
+func (idp *testIdP) provider(t *testing.T) *Provider {
+	t.Helper()
+	p, err := NewProvider(Config{
+		Issuer:      idp.srv.URL,
+		ClientID:    testClientID,
+		RedirectURL: testRedirect,
+		HTTPClient:  idp.srv.Client(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLoginFlowWithStub(t *testing.T) {
+	idp := newTestIdP(t)
+	p := idp.provider(t)
+	ctx := context.Background()
+
+	verifier, challenge, err := NewPKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authURL, err := p.AuthCodeURL(ctx, "state-1", testNonce, challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := idp.srv.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d, want 302", resp.StatusCode)
+	}
+	back, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := back.Query().Get("state"); got != "state-1" {
+		t.Fatalf("state = %q, want state-1", got)
+	}
+
+	raw, err := p.Exchange(ctx, back.Query().Get("code"), verifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	claims, err := p.VerifyIDToken(ctx, raw, testNonce)
+	if err != nil {
+		t.Fatalf("VerifyIDToken: %v", err)
+	}
+	if claims.Email != testEmail || !claims.EmailVerified || claims.Subject == "" {
+		t.Fatalf("claims = %+v", claims)
+	}
+}
+
+func TestExchangeRejectsWrongVerifier(t *testing.T) {
+	idp := newTestIdP(t)
+	p := idp.provider(t)
+	ctx := context.Background()
+
+	_, challenge, err := NewPKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authURL, err := p.AuthCodeURL(ctx, "s", testNonce, challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := idp.srv.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	back, _ := url.Parse(resp.Header.Get("Location"))
+
+	otherVerifier, _, err := NewPKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Exchange(ctx, back.Query().Get("code"), otherVerifier); err == nil {
+		t.Fatal("Exchange with a foreign code verifier: want error")
+	}
+}
+
+func b64(v []byte) string { return base64.RawURLEncoding.EncodeToString(v) }
+
+func b64JSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b64(b)
+}
+
+func TestVerifyIDTokenRejects(t *testing.T) {
+	idp := newTestIdP(t)
+	now := time.Now()
+
+	sign := func(t *testing.T, mutate func(map[string]any)) string {
+		t.Helper()
+		c := idp.stub.Claims(testEmail, testNonce, now)
+		if mutate != nil {
+			mutate(c)
+		}
+		tok, err := idp.stub.Sign(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+
+	tests := []struct {
+		name  string
+		token func(t *testing.T) string
+	}{
+		{"tampered payload", func(t *testing.T) string {
+			parts := strings.Split(sign(t, nil), ".")
+			c := idp.stub.Claims("intruder@example.com", testNonce, now)
+			return parts[0] + "." + b64JSON(t, c) + "." + parts[2]
+		}},
+		{"signature of another token", func(t *testing.T) string {
+			a := strings.Split(sign(t, nil), ".")
+			b := strings.Split(sign(t, func(c map[string]any) { c["email"] = "other@example.com" }), ".")
+			return a[0] + "." + a[1] + "." + b[2]
+		}},
+		{"wrong audience", func(t *testing.T) string {
+			return sign(t, func(c map[string]any) { c["aud"] = "someone-else" })
+		}},
+		{"several audiences without azp", func(t *testing.T) string {
+			return sign(t, func(c map[string]any) { c["aud"] = []string{testClientID, "someone-else"} })
+		}},
+		{"wrong issuer", func(t *testing.T) string {
+			return sign(t, func(c map[string]any) { c["iss"] = "https://evil.example" })
+		}},
+		{"expired", func(t *testing.T) string {
+			return sign(t, func(c map[string]any) { c["exp"] = now.Add(-2 * clockSkew).Unix() })
+		}},
+		{"no exp", func(t *testing.T) string {
+			return sign(t, func(c map[string]any) { delete(c, "exp") })
+		}},
+		{"issued in the future", func(t *testing.T) string {
+			return sign(t, func(c map[string]any) { c["iat"] = now.Add(2 * clockSkew).Unix() })
+		}},
+		{"nonce mismatch", func(t *testing.T) string {
+			return sign(t, func(c map[string]any) { c["nonce"] = "other-nonce" })
+		}},
+		{"no subject", func(t *testing.T) string {
+			return sign(t, func(c map[string]any) { c["sub"] = "" })
+		}},
+		{"alg none", func(t *testing.T) string {
+			h := b64JSON(t, map[string]string{"alg": "none", "kid": oidcstub.KeyID})
+			return h + "." + b64JSON(t, idp.stub.Claims(testEmail, testNonce, now)) + "."
+		}},
+		{"alg HS256 keyed with the public key", func(t *testing.T) string {
+			der, err := x509.MarshalPKIXPublicKey(idp.stub.PublicKey())
+			if err != nil {
+				t.Fatal(err)
+			}
+			input := b64JSON(t, map[string]string{"alg": "HS256", "kid": oidcstub.KeyID}) + "." +
+				b64JSON(t, idp.stub.Claims(testEmail, testNonce, now))
+			mac := hmac.New(sha256.New, der)
+			mac.Write([]byte(input))
+			return input + "." + b64(mac.Sum(nil))
+		}},
+		{"alg ES256 with an RSA key", func(t *testing.T) string {
+			parts := strings.Split(sign(t, nil), ".")
+			h := b64JSON(t, map[string]string{"alg": "ES256", "kid": oidcstub.KeyID})
+			return h + "." + parts[1] + "." + parts[2]
+		}},
+		{"unknown kid", func(t *testing.T) string {
+			parts := strings.Split(sign(t, nil), ".")
+			h := b64JSON(t, map[string]string{"alg": "RS256", "kid": "rotated-away"})
+			return h + "." + parts[1] + "." + parts[2]
+		}},
+		{"malformed", func(t *testing.T) string { return "not-a-jwt" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := idp.provider(t)
+			p.now = func() time.Time { return now }
+
+			_, err := p.VerifyIDToken(context.Background(), tt.token(t), testNonce)
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("VerifyIDToken: err = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestVerifyIDTokenAcceptsWithinSkew(t *testing.T) {
+	idp := newTestIdP(t)
+	issued := time.Now()
+	p := idp.provider(t)
+	p.now = func() time.Time { return issued.Add(5*time.Minute + clockSkew/2) }
+
+	c := idp.stub.Claims(testEmail, testNonce, issued)
+	c["aud"] = []string{testClientID, "someone-else"}
+	c["azp"] = testClientID
+	tok, err := idp.stub.Sign(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.VerifyIDToken(context.Background(), tok, testNonce); err != nil {
+		t.Fatalf("VerifyIDToken: %v", err)
+	}
+}
+
+func TestMetadataCache(t *testing.T) {
+	idp := newTestIdP(t)
+	p := idp.provider(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := p.metadata(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := idp.discovery.Load(); n != 1 {
+		t.Fatalf("discovery requests = %d, want 1 while cached", n)
+	}
+
+	now = now.Add(metadataTTL + time.Second)
+	if _, err := p.metadata(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := idp.discovery.Load(); n != 2 {
+		t.Fatalf("discovery requests = %d, want 2 after TTL", n)
+	}
+
+	// Провайдер недоступен после истечения TTL — остаются прежние метаданные.
+	idp.down.Store(true)
+	now = now.Add(metadataTTL + time.Second)
+	m, err := p.metadata(ctx)
+	if err != nil || m == nil || m.Issuer != idp.srv.URL {
+		t.Fatalf("metadata with IdP down = (%v, %v), want stale metadata", m, err)
+	}
+}
+
+func TestMetadataUnavailable(t *testing.T) {
+	idp := newTestIdP(t)
+	idp.down.Store(true)
+	p := idp.provider(t)
+
+	if _, err := p.AuthCodeURL(context.Background(), "s", "n", "c"); err == nil {
+		t.Fatal("AuthCodeURL with IdP down and no cache: want error")
+	}
+}
@@ -0,0 +1,270 @@
+// Package oidcstub — локальный провайдер OpenID Connect для разработки и тестов входа админов
+// (cmd/oidcstub и тесты пакета oidc). Страницы входа нет: authorize сразу выдаёт код для email
+// из login_hint (или Config.Email). Ключ подписи генерируется в New, поэтому токены живут до перезапуска.
+package oidcstub
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	codeTTL  = time.Minute
+	tokenTTL = 5 * time.Minute
+
+	// KeyID — kid ключа подписи в JWKS и заголовке ID token.
+	KeyID = "stub-1"
+)
+
+type Config struct {
+	Issuer       string
+	PublicURL    string // адрес для браузера, если сервис ходит к заглушке по внутреннему имени; пусто — Issuer
+	ClientID     string
+	ClientSecret string // пусто — секрет не проверяется
+	Email        string // email по умолчанию, если в authorize нет login_hint
+
+	Logger *slog.Logger
+}
+
+type authCode struct {
+	email       string
+	nonce       string
+	redirectURI string
+	challenge   string
+	expiresAt   time.Time
+}
+
+type Stub struct {
+	cfg Config
+	key *rsa.PrivateKey
+	log *slog.Logger
+
+	mu    sync.Mutex
+	codes map[string]authCode
+}
+
+func New(cfg Config) (*Stub, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" {
+		return nil, fmt.Errorf("oidcstub: issuer and client id are required")
+	}
+	if cfg.PublicURL == "" {
+		cfg.PublicURL = cfg.Issuer
+	}
+	log := cfg.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("oidcstub: generate key: %w", err)
+	}
+
+	return &Stub{cfg: cfg, key: key, log: log, codes: map[string]authCode{}}, nil
+}
+
+func (s *Stub) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("GET /authorize", s.authorize)
+	mux.HandleFunc("POST /token", s.token)
+	mux.HandleFunc("GET /jwks", s.jwks)
+	return mux
+}
+
+// PublicKey — ключ, которым подписываются ID token; публикуется в JWKS.
+func (s *Stub) PublicKey() *rsa.PublicKey {
+	return &s.key.PublicKey
+}
+
+func (s *Stub) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.cfg.Issuer,
+		"authorization_endpoint":                s.cfg.PublicURL + "/authorize",
+		"token_endpoint":                        s.cfg.Issuer + "/token",
+		"jwks_uri":                              s.cfg.Issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *Stub) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	redirectURI := q.Get("redirect_uri")
+	u, err := url.Parse(redirectURI)
+	if err != nil || redirectURI == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if q.Get("client_id") != s.cfg.ClientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+
+	back := u.Query()
+	back.Set("state", q.Get("state"))
+
+	switch {
+	case q.Get("response_type") != "code":
+		back.Set("error", "unsupported_response_type")
+	case q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256":
+		back.Set("error", "invalid_request")
+	default:
+		email := q.Get("login_hint")
+		if email == "" {
+			email = s.cfg.Email
+		}
+		code := randomString()
+		s.mu.Lock()
+		s.codes[code] = authCode{
+			email:       email,
+			nonce:       q.Get("nonce"),
+			redirectURI: redirectURI,
+			challenge:   q.Get("code_challenge"),
+			expiresAt:   time.Now().Add(codeTTL),
+		}
+		s.mu.Unlock()
+		back.Set("code", code)
+		s.log.Info("code issued", "email", email)
+	}
+
+	u.RawQuery = back.Encode()
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func (s *Stub) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+
+	clientID, secret, basic := r.BasicAuth()
+	if basic {
+		clientID, _ = url.QueryUnescape(clientID)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		clientID = r.PostForm.Get("client_id")
+		secret = r.PostForm.Get("client_secret")
+	}
+	if clientID != s.cfg.ClientID || (s.cfg.ClientSecret != "" && secret != s.cfg.ClientSecret) {
+		w.Header().Set("WWW-Authenticate", "Basic")
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	c, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case !ok || time.Now().After(c.expiresAt):
+		tokenError(w, "invalid_grant")
+		return
+	case c.redirectURI != r.PostForm.Get("redirect_uri"):
+		tokenError(w, "invalid_grant")
+		return
+	case base64.RawURLEncoding.EncodeToString(sum[:]) != c.challenge:
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	idToken, err := s.Sign(s.Claims(c.email, c.nonce, time.Now()))
+	if err != nil {
+		s.log.Error("sign id token failed", "err", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   int(tokenTTL.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func (s *Stub) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": KeyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// Claims — claims ID token, который заглушка выдаёт на момент now; тесты меняют их перед Sign.
+func (s *Stub) Claims(email, nonce string, now time.Time) map[string]any {
+	return map[string]any{
+		"iss":            s.cfg.Issuer,
+		"sub":            "stub|" + email,
+		"aud":            s.cfg.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(tokenTTL).Unix(),
+		"nonce":          nonce,
+		"email":          email,
+		"email_verified": true,
+	}
+}
+
+// Sign подписывает claims ключом заглушки (RS256, kid = KeyID).
+func (s *Stub) Sign(claims map[string]any) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": KeyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -10,31 +10,353 @@ import (
 )
 
 type CreateBookingParams struct {
+	Kind   domain.BookingKind   // пусто = client
+	Status domain.BookingStatus // пусто = confirmed
+
 	StartAt time.Time
 	EndAt   time.Time
 
+	// Интервал с учётом буферов; нулевые значения = StartAt/EndAt.
+	OccupiedStartAt time.Time
+	OccupiedEndAt   time.Time
+
 	ClientName  string
 	ClientPhone string
 	Comment     *string
+
+	ServiceID   *uuid.UUID
+	ServiceName *string
+	PriceRub    *int
+
+	// ManageTokenHash — sha256 токена ссылки управления записью; пусто для блокировок.
+	ManageTokenHash string
+
+	// PendingExpiresAt — срок заявки; обязателен для Status = pending.
+	PendingExpiresAt *time.Time
+
+	// HoldTokenHash — sha256 токена удержания слота, которое выкупается этой записью
+	// в той же транзакции; пусто — без удержания.
+	HoldTokenHash string
+
+	// StudioDate — день студии, который проверяется на закрытие в той же транзакции
+	// (ErrStudioClosed); нулевое значение — без проверки.
+	StudioDate time.Time
 }
 
+// ListBookingsByRangeParams — выбираются записи, чей занятый интервал (с буферами) пересекается с диапазоном.
+// Истёкшие удержания слотов не возвращаются, даже если фоновая задача ещё не успела их удалить.
 type ListBookingsByRangeParams struct {
 	RangeStart time.Time
 	RangeEnd   time.Time
 }
 
-type CancelBookingParams struct {
+// UpdateBookingStatusParams — переход в состояние To, если текущее состояние входит в From.
+type UpdateBookingStatusParams struct {
 	ID     uuid.UUID
+	From   []domain.BookingStatus
+	To     domain.BookingStatus
 	NowUTC time.Time
-	Reason *string
+
+	// Только для отмены.
+	Reason      *string
+	CancelledBy *string
+	Source      domain.BookingActor
+}
+
+type CreateSlotHoldParams struct {
+	StartAt         time.Time
+	EndAt           time.Time
+	OccupiedStartAt time.Time
+	OccupiedEndAt   time.Time
+
+	TokenHash string
+	ExpiresAt time.Time
+
+	// ReplaceTokenHash — прежнее удержание того же клиента, снимаемое в той же транзакции; пусто — нет.
+	ReplaceTokenHash string
+
+	// Requester — кто удерживает (IP клиента); пусто — без лимитов. Не больше MaxRequests удержаний
+	// с RateWindowStart и не больше MaxActive действующих одновременно, иначе TooManyRequestsError.
+	Requester       string
+	RateWindowStart time.Time
+	MaxRequests     int
+	MaxActive       int
+
+	// StudioDate — как в CreateBookingParams.
+	StudioDate time.Time
+}
+
+// RescheduleBookingParams — новое время сеанса; прежнее сохраняется в истории переносов.
+type RescheduleBookingParams struct {
+	ID uuid.UUID
+
+	StartAt         time.Time
+	EndAt           time.Time
+	OccupiedStartAt time.Time
+	OccupiedEndAt   time.Time
+
+	Source        domain.BookingActor
+	RescheduledBy *string
+
+	// StudioDate — как в CreateBookingParams.
+	StudioDate time.Time
 }
 
 type BookingRepository interface {
+	// Create привязывает клиентскую запись к клиенту справочника по телефону (новый телефон — новый клиент).
+	// При HoldTokenHash удаляет действующее удержание и вставляет запись одной транзакцией;
+	// если удержание истекло или уже выкуплено — ErrNotFound.
 	Create(ctx context.Context, p CreateBookingParams) (domain.Booking, error)
 
 	GetByID(ctx context.Context, id uuid.UUID) (domain.Booking, error)
 
+	// GetByManageToken ищет клиентскую запись по id и хэшу токена; при несовпадении — ErrNotFound.
+	GetByManageToken(ctx context.Context, id uuid.UUID, tokenHash string) (domain.Booking, error)
+	// SetManageTokenHash заменяет хэш токена ссылки управления; не клиентская запись — ErrNotFound.
+	SetManageTokenHash(ctx context.Context, id uuid.UUID, tokenHash string) error
+
 	ListByRange(ctx context.Context, p ListBookingsByRangeParams) ([]domain.Booking, error)
 
-	Cancel(ctx context.Context, p CancelBookingParams) (domain.Booking, error)
+	// UpdateStatus меняет состояние только клиентской записи; для блокировки, неизвестной записи
+	// или записи, чьё состояние не входит в From, возвращает ErrNotFound.
+	UpdateStatus(ctx context.Context, p UpdateBookingStatusParams) (domain.Booking, error)
+
+	DeleteBlock(ctx context.Context, id uuid.UUID) error
+
+	// CreateHold занимает интервал до ExpiresAt; пересечение с записью или другим удержанием — ErrTimeTaken
+	// (при ошибке прежнее удержание ReplaceTokenHash остаётся в силе).
+	CreateHold(ctx context.Context, p CreateSlotHoldParams) (domain.SlotHold, error)
+	// GetHold ищет действующее к nowUTC удержание по хэшу токена; иначе — ErrNotFound.
+	GetHold(ctx context.Context, tokenHash string, nowUTC time.Time) (domain.SlotHold, error)
+	DeleteExpiredHolds(ctx context.Context, nowUTC time.Time) (int64, error)
+	// DeleteHoldRequestsBefore чистит журнал удержаний для лимита частоты.
+	DeleteHoldRequestsBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// ListPending — заявки, ждущие подтверждения, по времени начала.
+	ListPending(ctx context.Context) ([]domain.Booking, error)
+
+	// ExpirePending отменяет заявки, чей срок истёк к nowUTC (cancel_source = system).
+	ExpirePending(ctx context.Context, nowUTC time.Time, reason string) (int64, error)
+
+	// Reschedule переносит незавершённую клиентскую запись одной транзакцией и пишет историю.
+	// Срок заявки (pending_expires_at) сохраняется, но не позже нового StartAt.
+	// Пересечение с другими записями — ErrTimeTaken (bookings_no_overlap_active), отменённая запись или блокировка — ErrNotFound.
+	Reschedule(ctx context.Context, p RescheduleBookingParams) (domain.Booking, error)
+	ListReschedules(ctx context.Context, bookingID uuid.UUID) ([]domain.BookingReschedule, error)
+}
+
+type ReserveIdempotencyKeyParams struct {
+	Scope       string
+	Key         string
+	RequestHash string
+
+	NowUTC      time.Time
+	ExpiresAt   time.Time
+	StaleBefore time.Time
+}
+
+type CompleteIdempotencyKeyParams struct {
+	Scope string
+	Key   string
+
+	StatusCode   int
+	ResponseBody []byte
+	NowUTC       time.Time
+}
+
+type IdempotencyRepository interface {
+	// Reserve возвращает reserved=true, если ключ был свободен (или просрочен) и теперь занят текущим запросом.
+	Reserve(ctx context.Context, p ReserveIdempotencyKeyParams) (rec domain.IdempotencyRecord, reserved bool, err error)
+
+	Complete(ctx context.Context, p CompleteIdempotencyKeyParams) error
+
+	Release(ctx context.Context, scope, key string) error
+
+	DeleteExpired(ctx context.Context, nowUTC time.Time) (int64, error)
+}
+
+type CreateClosureParams struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Reason    string
+
+	// Границы закрываемых дней в UTC; активные клиентские записи в них не дают создать закрытие.
+	BookingsFrom time.Time
+	BookingsTo   time.Time
+}
+
+type ListClosuresByRangeParams struct {
+	// Даты включительно; возвращаются закрытия, пересекающиеся с диапазоном.
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+type ClosureRepository interface {
+	// Create проверяет записи и вставляет закрытие одной транзакцией, сериализованной с созданием
+	// и переносом записей; пересечение с активными клиентскими записями — BookingsConflictError.
+	Create(ctx context.Context, p CreateClosureParams) (domain.Closure, error)
+
+	ListByRange(ctx context.Context, p ListClosuresByRangeParams) ([]domain.Closure, error)
+
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type ListClientsParams struct {
+	// Query — подстрока имени (без учёта регистра); PhoneDigits — подстрока цифр телефона.
+	// Оба пустые — все клиенты; иначе достаточно совпадения по одному.
+	Query       string
+	PhoneDigits string
+
+	Limit  int
+	Offset int
+}
+
+type ClientRepository interface {
+	// List — клиенты без слитых дубликатов, сначала с самым недавним визитом.
+	List(ctx context.Context, p ListClientsParams) ([]domain.Client, error)
+
+	// GetByID для слитого дубликата возвращает ErrNotFound.
+	GetByID(ctx context.Context, id uuid.UUID) (domain.Client, error)
+
+	// Merge одной транзакцией переносит записи дубликатов к targetID и помечает дубликаты слитыми.
+	// Если target или один из дубликатов не найден или уже слит — ErrNotFound.
+	Merge(ctx context.Context, targetID uuid.UUID, duplicateIDs []uuid.UUID) (domain.Client, error)
+}
+
+type StudioServiceParams struct {
+	Name            string
+	Description     *string
+	DurationMinutes int
+	PriceRub        int
+	IsActive        bool
+
+	RequiresApproval *bool
+}
+
+type StudioServiceRepository interface {
+	Create(ctx context.Context, p StudioServiceParams) (domain.StudioService, error)
+
+	GetByID(ctx context.Context, id uuid.UUID) (domain.StudioService, error)
+
+	List(ctx context.Context, activeOnly bool) ([]domain.StudioService, error)
+
+	Update(ctx context.Context, id uuid.UUID, p StudioServiceParams) (domain.StudioService, error)
+
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type CreateAdminSessionParams struct {
+	TokenHash  string
+	AdminID    uuid.UUID
+	MFAPending bool
+
+	NowUTC    time.Time
+	ExpiresAt time.Time
+
+	IP        string
+	UserAgent string
+}
+
+// AdminSessionRepository — сессии ищутся по хэшу токена; idleSince — граница простоя:
+// сессия с last_seen_at не позже неё считается истёкшей.
+type AdminSessionRepository interface {
+	Create(ctx context.Context, p CreateAdminSessionParams) (domain.AdminSession, error)
+
+	// Touch обновляет last_seen_at действующей сессии; для неизвестной или истёкшей — ErrNotFound.
+	Touch(ctx context.Context, tokenHash string, nowUTC, idleSince time.Time) (domain.AdminSession, error)
+
+	ListActive(ctx context.Context, nowUTC, idleSince time.Time) ([]domain.AdminSession, error)
+
+	// CompleteMFA снимает признак mfa_pending и выставляет новый абсолютный срок.
+	CompleteMFA(ctx context.Context, tokenHash string, nowUTC, expiresAt time.Time) (domain.AdminSession, error)
+
+	DeleteByTokenHash(ctx context.Context, tokenHash string) error
+
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	DeleteExpired(ctx context.Context, nowUTC, idleSince time.Time) (int64, error)
+}
+
+type AdminRepository interface {
+	Create(ctx context.Context, username, passwordHash string, role domain.AdminRole) (domain.Admin, error)
+
+	GetByID(ctx context.Context, id uuid.UUID) (domain.Admin, error)
+
+	GetByUsername(ctx context.Context, username string) (domain.Admin, error)
+
+	// UpdatePassword меняет пароль и той же транзакцией удаляет сессии администратора
+	// из таблицы sessions и отзывает выпущенные им API-ключи.
+	UpdatePassword(ctx context.Context, username, passwordHash string) (domain.Admin, error)
+
+	UpdateRole(ctx context.Context, username string, role domain.AdminRole) (domain.Admin, error)
+
+	Count(ctx context.Context) (int, error)
+
+	// SetTOTP записывает секрет и момент включения; nil в обоих полях отключает TOTP и удаляет коды восстановления.
+	SetTOTP(ctx context.Context, id uuid.UUID, secret *string, enabledAt *time.Time) error
+
+	// UseTOTPStep атомарно запоминает использованный интервал; false — код этого или более позднего интервала уже был.
+	UseTOTPStep(ctx context.Context, id uuid.UUID, step int64) (bool, error)
+
+	ReplaceRecoveryCodes(ctx context.Context, id uuid.UUID, codeHashes []string) error
+
+	// UseRecoveryCode гасит неиспользованный код; false — такого кода нет или он уже использован.
+	UseRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string, nowUTC time.Time) (bool, error)
+
+	CountRecoveryCodes(ctx context.Context, id uuid.UUID) (int, error)
+}
+
+type RegisterLoginFailureParams struct {
+	Scope domain.LoginScope
+	Key   string
+
+	NowUTC time.Time
+	// Счётчик обнуляется, если и последняя неудача, и блокировка закончились раньше WindowStart.
+	WindowStart time.Time
+}
+
+type LockLoginParams struct {
+	Scope       domain.LoginScope
+	Key         string
+	Failures    int
+	LockedUntil time.Time
+}
+
+type LoginAttemptRepository interface {
+	// LockedUntil возвращает самую позднюю действующую блокировку по IP или логину (нулевое время — блокировок нет).
+	LockedUntil(ctx context.Context, nowUTC time.Time, ip, username string) (time.Time, error)
+
+	// RegisterFailure увеличивает счётчик и возвращает число неудач подряд.
+	RegisterFailure(ctx context.Context, p RegisterLoginFailureParams) (int, error)
+
+	// Lock выставляет блокировку и пишет её в журнал.
+	Lock(ctx context.Context, p LockLoginParams) (domain.LoginLockout, error)
+
+	Reset(ctx context.Context, scope domain.LoginScope, key string) error
+
+	DeleteStale(ctx context.Context, nowUTC, before time.Time) (int64, error)
+
+	ListLockouts(ctx context.Context, limit int) ([]domain.LoginLockout, error)
+}
+
+type CreateAPIKeyParams struct {
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []domain.Permission
+	CreatedBy uuid.UUID
+	NowUTC    time.Time
+	ExpiresAt *time.Time
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, p CreateAPIKeyParams) (domain.APIKey, error)
+
+	List(ctx context.Context) ([]domain.APIKey, error)
+
+	// Use находит действующий ключ по хэшу и обновляет last_used_at; неизвестный, отозванный или истёкший — ErrNotFound.
+	Use(ctx context.Context, keyHash string, nowUTC time.Time) (domain.APIKey, error)
+
+	// Revoke отзывает ключ; повторный отзыв не меняет revoked_at.
+	Revoke(ctx context.Context, id uuid.UUID, nowUTC time.Time) error
 }
@@ -0,0 +1,302 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type AdminRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAdminRepository(pool *pgxpool.Pool) *AdminRepository {
+	return &AdminRepository{pool: pool}
+}
+
+var _ repository.AdminRepository = (*AdminRepository)(nil)
+
+const qCreateAdmin = `
+INSERT INTO admins (username, password_hash, role)
+VALUES ($1, $2, $3)
+RETURNING id, username, role, password_hash, totp_secret, totp_enabled_at, created_at, updated_at;
+`
+
+const qGetAdminByID = `
+SELECT id, username, role, password_hash, totp_secret, totp_enabled_at, created_at, updated_at
+FROM admins
+WHERE id = $1;
+`
+
+const qGetAdminByUsername = `
+SELECT id, username, role, password_hash, totp_secret, totp_enabled_at, created_at, updated_at
+FROM admins
+WHERE username = $1;
+`
+
+const qUpdateAdminPassword = `
+UPDATE admins
+SET password_hash = $2, updated_at = now()
+WHERE username = $1
+RETURNING id, username, role, password_hash, totp_secret, totp_enabled_at, created_at, updated_at;
+`
+
+// Сессии и API-ключи, выданные до смены пароля, больше не действуют.
+const qDeleteAdminSessions = `
+DELETE FROM sessions
+WHERE admin_id = $1;
+`
+
+const qRevokeAdminAPIKeys = `
+UPDATE api_keys
+SET revoked_at = now()
+WHERE created_by = $1 AND revoked_at IS NULL;
+`
+
+const qUpdateAdminRole = `
+UPDATE admins
+SET role = $2, updated_at = now()
+WHERE username = $1
+RETURNING id, username, role, password_hash, totp_secret, totp_enabled_at, created_at, updated_at;
+`
+
+const qCountAdmins = `
+SELECT count(*) FROM admins;
+`
+
+const qSetAdminTOTP = `
+UPDATE admins
+SET totp_secret = $2, totp_enabled_at = $3, totp_last_step = NULL, updated_at = now()
+WHERE id = $1;
+`
+
+const qDeleteAdminRecoveryCodes = `
+DELETE FROM admin_recovery_codes
+WHERE admin_id = $1;
+`
+
+const qUseAdminTOTPStep = `
+UPDATE admins
+SET totp_last_step = $2
+WHERE id = $1
+  AND (totp_last_step IS NULL OR totp_last_step < $2);
+`
+
+const qInsertAdminRecoveryCode = `
+INSERT INTO admin_recovery_codes (admin_id, code_hash)
+VALUES ($1, $2);
+`
+
+const qUseAdminRecoveryCode = `
+UPDATE admin_recovery_codes
+SET used_at = $3
+WHERE admin_id = $1
+  AND code_hash = $2
+  AND used_at IS NULL;
+`
+
+const qCountAdminRecoveryCodes = `
+SELECT count(*)
+FROM admin_recovery_codes
+WHERE admin_id = $1
+  AND used_at IS NULL;
+`
+
+func scanAdmin(s rowScanner) (domain.Admin, error) {
+	var a domain.Admin
+	var role string
+	if err := s.Scan(
+		&a.ID,
+		&a.Username,
+		&role,
+		&a.PasswordHash,
+		&a.TOTPSecret,
+		&a.TOTPEnabledAt,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	); err != nil {
+		return domain.Admin{}, err
+	}
+	a.Role = domain.AdminRole(role)
+	return a, nil
+}
+
+func (r *AdminRepository) Create(ctx context.Context, username, passwordHash string, role domain.AdminRole) (domain.Admin, error) {
+	if r.pool == nil {
+		return domain.Admin{}, fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	a, err := scanAdmin(r.pool.QueryRow(ctx, qCreateAdmin, username, passwordHash, string(role)))
+	if err != nil {
+		return domain.Admin{}, mapPgError(err)
+	}
+	return a, nil
+}
+
+func (r *AdminRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.Admin, error) {
+	if r.pool == nil {
+		return domain.Admin{}, fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	a, err := scanAdmin(r.pool.QueryRow(ctx, qGetAdminByID, id))
+	if err != nil {
+		return domain.Admin{}, mapPgError(err)
+	}
+	return a, nil
+}
+
+func (r *AdminRepository) GetByUsername(ctx context.Context, username string) (domain.Admin, error) {
+	if r.pool == nil {
+		return domain.Admin{}, fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	a, err := scanAdmin(r.pool.QueryRow(ctx, qGetAdminByUsername, username))
+	if err != nil {
+		return domain.Admin{}, mapPgError(err)
+	}
+	return a, nil
+}
+
+func (r *AdminRepository) UpdatePassword(ctx context.Context, username, passwordHash string) (domain.Admin, error) {
+	if r.pool == nil {
+		return domain.Admin{}, fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Admin{}, mapPgError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	a, err := scanAdmin(tx.QueryRow(ctx, qUpdateAdminPassword, username, passwordHash))
+	if err != nil {
+		return domain.Admin{}, mapPgError(err)
+	}
+	if _, err := tx.Exec(ctx, qDeleteAdminSessions, a.ID); err != nil {
+		return domain.Admin{}, mapPgError(err)
+	}
+	if _, err := tx.Exec(ctx, qRevokeAdminAPIKeys, a.ID); err != nil {
+		return domain.Admin{}, mapPgError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Admin{}, mapPgError(err)
+	}
+	return a, nil
+}
+
+func (r *AdminRepository) UpdateRole(ctx context.Context, username string, role domain.AdminRole) (domain.Admin, error) {
+	if r.pool == nil {
+		return domain.Admin{}, fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	a, err := scanAdmin(r.pool.QueryRow(ctx, qUpdateAdminRole, username, string(role)))
+	if err != nil {
+		return domain.Admin{}, mapPgError(err)
+	}
+	return a, nil
+}
+
+func (r *AdminRepository) Count(ctx context.Context) (int, error) {
+	if r.pool == nil {
+		return 0, fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	var n int
+	if err := r.pool.QueryRow(ctx, qCountAdmins).Scan(&n); err != nil {
+		return 0, mapPgError(err)
+	}
+	return n, nil
+}
+
+func (r *AdminRepository) SetTOTP(ctx context.Context, id uuid.UUID, secret *string, enabledAt *time.Time) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return mapPgError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, qSetAdminTOTP, id, secret, enabledAt)
+	if err != nil {
+		return mapPgError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	if secret == nil {
+		if _, err := tx.Exec(ctx, qDeleteAdminRecoveryCodes, id); err != nil {
+			return mapPgError(err)
+		}
+	}
+
+	return mapPgError(tx.Commit(ctx))
+}
+
+func (r *AdminRepository) UseTOTPStep(ctx context.Context, id uuid.UUID, step int64) (bool, error) {
+	if r.pool == nil {
+		return false, fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qUseAdminTOTPStep, id, step)
+	if err != nil {
+		return false, mapPgError(err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *AdminRepository) ReplaceRecoveryCodes(ctx context.Context, id uuid.UUID, codeHashes []string) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return mapPgError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, qDeleteAdminRecoveryCodes, id); err != nil {
+		return mapPgError(err)
+	}
+	for _, h := range codeHashes {
+		if _, err := tx.Exec(ctx, qInsertAdminRecoveryCode, id, h); err != nil {
+			return mapPgError(err)
+		}
+	}
+
+	return mapPgError(tx.Commit(ctx))
+}
+
+func (r *AdminRepository) UseRecoveryCode(ctx context.Context, id uuid.UUID, codeHash string, nowUTC time.Time) (bool, error) {
+	if r.pool == nil {
+		return false, fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qUseAdminRecoveryCode, id, codeHash, nowUTC)
+	if err != nil {
+		return false, mapPgError(err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *AdminRepository) CountRecoveryCodes(ctx context.Context, id uuid.UUID) (int, error) {
+	if r.pool == nil {
+		return 0, fmt.Errorf("postgres: admin repo: pool is nil")
+	}
+
+	var n int
+	if err := r.pool.QueryRow(ctx, qCountAdminRecoveryCodes, id).Scan(&n); err != nil {
+		return 0, mapPgError(err)
+	}
+	return n, nil
+}
@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type AdminSessionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAdminSessionRepository(pool *pgxpool.Pool) *AdminSessionRepository {
+	return &AdminSessionRepository{pool: pool}
+}
+
+var _ repository.AdminSessionRepository = (*AdminSessionRepository)(nil)
+
+const qCreateAdminSession = `
+WITH s AS (
+  INSERT INTO sessions (token_hash, admin_id, created_at, last_seen_at, expires_at, ip, user_agent, mfa_pending)
+  VALUES ($1, $2, $3, $3, $4, $5, $6, $7)
+  RETURNING id, admin_id, created_at, last_seen_at, expires_at, ip, user_agent, mfa_pending
+)
+SELECT s.id, s.admin_id, a.username, a.role, s.created_at, s.last_seen_at, s.expires_at, s.ip, s.user_agent, s.mfa_pending
+FROM s
+JOIN admins a ON a.id = s.admin_id;
+`
+
+const qTouchAdminSession = `
+WITH s AS (
+  UPDATE sessions
+  SET last_seen_at = $2
+  WHERE token_hash = $1
+    AND expires_at > $2
+    AND last_seen_at > $3
+  RETURNING id, admin_id, created_at, last_seen_at, expires_at, ip, user_agent, mfa_pending
+)
+SELECT s.id, s.admin_id, a.username, a.role, s.created_at, s.last_seen_at, s.expires_at, s.ip, s.user_agent, s.mfa_pending
+FROM s
+JOIN admins a ON a.id = s.admin_id;
+`
+
+const qListActiveAdminSessions = `
+SELECT s.id, s.admin_id, a.username, a.role, s.created_at, s.last_seen_at, s.expires_at, s.ip, s.user_agent, s.mfa_pending
+FROM sessions s
+JOIN admins a ON a.id = s.admin_id
+WHERE s.expires_at > $1
+  AND s.last_seen_at > $2
+ORDER BY s.created_at DESC;
+`
+
+const qCompleteAdminSessionMFA = `
+WITH s AS (
+  UPDATE sessions
+  SET mfa_pending = false, last_seen_at = $2, expires_at = $3
+  WHERE token_hash = $1
+    AND mfa_pending
+    AND expires_at > $2
+  RETURNING id, admin_id, created_at, last_seen_at, expires_at, ip, user_agent, mfa_pending
+)
+SELECT s.id, s.admin_id, a.username, a.role, s.created_at, s.last_seen_at, s.expires_at, s.ip, s.user_agent, s.mfa_pending
+FROM s
+JOIN admins a ON a.id = s.admin_id;
+`
+
+const qDeleteAdminSessionByTokenHash = `
+DELETE FROM sessions
+WHERE token_hash = $1;
+`
+
+const qDeleteAdminSession = `
+DELETE FROM sessions
+WHERE id = $1;
+`
+
+const qDeleteExpiredAdminSessions = `
+DELETE FROM sessions
+WHERE expires_at <= $1
+   OR last_seen_at <= $2;
+`
+
+func scanAdminSession(s rowScanner) (domain.AdminSession, error) {
+	var sess domain.AdminSession
+	var role string
+	if err := s.Scan(
+		&sess.ID,
+		&sess.AdminID,
+		&sess.AdminUsername,
+		&role,
+		&sess.CreatedAt,
+		&sess.LastSeenAt,
+		&sess.ExpiresAt,
+		&sess.IP,
+		&sess.UserAgent,
+		&sess.MFAPending,
+	); err != nil {
+		return domain.AdminSession{}, err
+	}
+	sess.AdminRole = domain.AdminRole(role)
+	return sess, nil
+}
+
+func (r *AdminSessionRepository) Create(ctx context.Context, p repository.CreateAdminSessionParams) (domain.AdminSession, error) {
+	if r.pool == nil {
+		return domain.AdminSession{}, fmt.Errorf("postgres: admin session repo: pool is nil")
+	}
+
+	row := r.pool.QueryRow(ctx, qCreateAdminSession, p.TokenHash, p.AdminID, p.NowUTC, p.ExpiresAt, p.IP, p.UserAgent, p.MFAPending)
+
+	sess, err := scanAdminSession(row)
+	if err != nil {
+		return domain.AdminSession{}, mapPgError(err)
+	}
+	return sess, nil
+}
+
+func (r *AdminSessionRepository) Touch(ctx context.Context, tokenHash string, nowUTC, idleSince time.Time) (domain.AdminSession, error) {
+	if r.pool == nil {
+		return domain.AdminSession{}, fmt.Errorf("postgres: admin session repo: pool is nil")
+	}
+
+	row := r.pool.QueryRow(ctx, qTouchAdminSession, tokenHash, nowUTC, idleSince)
+
+	sess, err := scanAdminSession(row)
+	if err != nil {
+		return domain.AdminSession{}, mapPgError(err)
+	}
+	return sess, nil
+}
+
+func (r *AdminSessionRepository) ListActive(ctx context.Context, nowUTC, idleSince time.Time) ([]domain.AdminSession, error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("postgres: admin session repo: pool is nil")
+	}
+
+	rows, err := r.pool.Query(ctx, qListActiveAdminSessions, nowUTC, idleSince)
+	if err != nil {
+		return nil, mapPgError(err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.AdminSession, 0, 8)
+	for rows.Next() {
+		sess, err := scanAdminSession(rows)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		out = append(out, sess)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapPgError(err)
+	}
+
+	return out, nil
+}
+
+func (r *AdminSessionRepository) CompleteMFA(ctx context.Context, tokenHash string, nowUTC, expiresAt time.Time) (domain.AdminSession, error) {
+	if r.pool == nil {
+		return domain.AdminSession{}, fmt.Errorf("postgres: admin session repo: pool is nil")
+	}
+
+	row := r.pool.QueryRow(ctx, qCompleteAdminSessionMFA, tokenHash, nowUTC, expiresAt)
+
+	sess, err := scanAdminSession(row)
+	if err != nil {
+		return domain.AdminSession{}, mapPgError(err)
+	}
+	return sess, nil
+}
+
+func (r *AdminSessionRepository) DeleteByTokenHash(ctx context.Context, tokenHash string) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: admin session repo: pool is nil")
+	}
+
+	if _, err := r.pool.Exec(ctx, qDeleteAdminSessionByTokenHash, tokenHash); err != nil {
+		return mapPgError(err)
+	}
+	return nil
+}
+
+func (r *AdminSessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: admin session repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qDeleteAdminSession, id)
+	if err != nil {
+		return mapPgError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AdminSessionRepository) DeleteExpired(ctx context.Context, nowUTC, idleSince time.Time) (int64, error) {
+	if r.pool == nil {
+		return 0, fmt.Errorf("postgres: admin session repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qDeleteExpiredAdminSessions, nowUTC, idleSince)
+	if err != nil {
+		return 0, mapPgError(err)
+	}
+	return tag.RowsAffected(), nil
+}
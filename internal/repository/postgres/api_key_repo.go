@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{pool: pool}
+}
+
+var _ repository.APIKeyRepository = (*APIKeyRepository)(nil)
+
+const qCreateAPIKey = `
+WITH k AS (
+  INSERT INTO api_keys (name, prefix, key_hash, scopes, created_by, created_at, expires_at)
+  VALUES ($1, $2, $3, $4, $5, $6, $7)
+  RETURNING id, name, prefix, scopes, created_by, created_at, expires_at, last_used_at, revoked_at
+)
+SELECT k.id, k.name, k.prefix, k.scopes, k.created_by, a.username, a.role, k.created_at, k.expires_at, k.last_used_at, k.revoked_at
+FROM k
+JOIN admins a ON a.id = k.created_by;
+`
+
+const qListAPIKeys = `
+SELECT k.id, k.name, k.prefix, k.scopes, k.created_by, a.username, a.role, k.created_at, k.expires_at, k.last_used_at, k.revoked_at
+FROM api_keys k
+JOIN admins a ON a.id = k.created_by
+ORDER BY k.created_at DESC;
+`
+
+const qUseAPIKey = `
+WITH k AS (
+  UPDATE api_keys
+  SET last_used_at = $2
+  WHERE key_hash = $1
+    AND revoked_at IS NULL
+    AND (expires_at IS NULL OR expires_at > $2)
+  RETURNING id, name, prefix, scopes, created_by, created_at, expires_at, last_used_at, revoked_at
+)
+SELECT k.id, k.name, k.prefix, k.scopes, k.created_by, a.username, a.role, k.created_at, k.expires_at, k.last_used_at, k.revoked_at
+FROM k
+JOIN admins a ON a.id = k.created_by;
+`
+
+const qRevokeAPIKey = `
+UPDATE api_keys
+SET revoked_at = COALESCE(revoked_at, $2)
+WHERE id = $1;
+`
+
+func scanAPIKey(s rowScanner) (domain.APIKey, error) {
+	var k domain.APIKey
+	var scopes []string
+	var role string
+	if err := s.Scan(
+		&k.ID,
+		&k.Name,
+		&k.Prefix,
+		&scopes,
+		&k.CreatedBy,
+		&k.CreatedByUsername,
+		&role,
+		&k.CreatedAt,
+		&k.ExpiresAt,
+		&k.LastUsedAt,
+		&k.RevokedAt,
+	); err != nil {
+		return domain.APIKey{}, err
+	}
+
+	k.CreatedByRole = domain.AdminRole(role)
+	k.Scopes = make([]domain.Permission, 0, len(scopes))
+	for _, sc := range scopes {
+		k.Scopes = append(k.Scopes, domain.Permission(sc))
+	}
+	return k, nil
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, p repository.CreateAPIKeyParams) (domain.APIKey, error) {
+	if r.pool == nil {
+		return domain.APIKey{}, fmt.Errorf("postgres: api key repo: pool is nil")
+	}
+
+	scopes := make([]string, 0, len(p.Scopes))
+	for _, sc := range p.Scopes {
+		scopes = append(scopes, string(sc))
+	}
+
+	row := r.pool.QueryRow(ctx, qCreateAPIKey, p.Name, p.Prefix, p.KeyHash, scopes, p.CreatedBy, p.NowUTC, p.ExpiresAt)
+
+	k, err := scanAPIKey(row)
+	if err != nil {
+		return domain.APIKey{}, mapPgError(err)
+	}
+	return k, nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("postgres: api key repo: pool is nil")
+	}
+
+	rows, err := r.pool.Query(ctx, qListAPIKeys)
+	if err != nil {
+		return nil, mapPgError(err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.APIKey, 0, 8)
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		out = append(out, k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapPgError(err)
+	}
+
+	return out, nil
+}
+
+func (r *APIKeyRepository) Use(ctx context.Context, keyHash string, nowUTC time.Time) (domain.APIKey, error) {
+	if r.pool == nil {
+		return domain.APIKey{}, fmt.Errorf("postgres: api key repo: pool is nil")
+	}
+
+	k, err := scanAPIKey(r.pool.QueryRow(ctx, qUseAPIKey, keyHash, nowUTC))
+	if err != nil {
+		return domain.APIKey{}, mapPgError(err)
+	}
+	return k, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, nowUTC time.Time) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: api key repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qRevokeAPIKey, id, nowUTC)
+	if err != nil {
+		return mapPgError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
 	"github.com/jackc/pgx/v5"
 
 	"github.com/google/uuid"
@@ -24,72 +26,340 @@ func NewBookingRepository(pool *pgxpool.Pool) *BookingRepository {
 var _ repository.BookingRepository = (*BookingRepository)(nil)
 
 const qCreateBooking = `
-INSERT INTO bookings (start_at, end_at, client_name, client_phone, comment)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO bookings (kind, start_at, end_at, occupied_start_at, occupied_end_at, client_name, client_phone, comment,
+                      service_id, service_name, price_rub, manage_token_hash, status, confirmed_at, pending_expires_at,
+                      client_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, CASE WHEN $13 = 'pending' THEN NULL ELSE now() END, $14,
+        $15)
 RETURNING
   id,
+  kind,
   start_at,
   end_at,
-  client_name,
-  client_phone,
+  occupied_start_at,
+  occupied_end_at,
+  COALESCE(client_name, ''),
+  COALESCE(client_phone, ''),
+  client_id,
   comment,
+  service_id,
+  service_name,
+  price_rub,
   status,
+  pending_expires_at,
   created_at,
+  confirmed_at,
+  completed_at,
+  no_show_at,
   cancelled_at,
-  cancel_reason;
+  cancel_reason,
+  cancelled_by,
+  cancel_source;
 `
 
 const qGetBookingByID = `
 SELECT
   id,
+  kind,
   start_at,
   end_at,
-  client_name,
-  client_phone,
+  occupied_start_at,
+  occupied_end_at,
+  COALESCE(client_name, ''),
+  COALESCE(client_phone, ''),
+  client_id,
   comment,
+  service_id,
+  service_name,
+  price_rub,
   status,
+  pending_expires_at,
   created_at,
+  confirmed_at,
+  completed_at,
+  no_show_at,
   cancelled_at,
-  cancel_reason
+  cancel_reason,
+  cancelled_by,
+  cancel_source
 FROM bookings
 WHERE id = $1;
 `
 
+const qGetBookingByManageToken = `
+SELECT
+  id,
+  kind,
+  start_at,
+  end_at,
+  occupied_start_at,
+  occupied_end_at,
+  COALESCE(client_name, ''),
+  COALESCE(client_phone, ''),
+  client_id,
+  comment,
+  service_id,
+  service_name,
+  price_rub,
+  status,
+  pending_expires_at,
+  created_at,
+  confirmed_at,
+  completed_at,
+  no_show_at,
+  cancelled_at,
+  cancel_reason,
+  cancelled_by,
+  cancel_source
+FROM bookings
+WHERE id = $1 AND kind = 'client' AND manage_token_hash = $2;
+`
+
+const qSetBookingManageToken = `
+UPDATE bookings
+SET manage_token_hash = $2
+WHERE id = $1 AND kind = 'client';
+`
+
 const qListBookingsByRange = `
 SELECT
   id,
+  kind,
   start_at,
   end_at,
-  client_name,
-  client_phone,
+  occupied_start_at,
+  occupied_end_at,
+  COALESCE(client_name, ''),
+  COALESCE(client_phone, ''),
+  client_id,
   comment,
+  service_id,
+  service_name,
+  price_rub,
   status,
+  pending_expires_at,
   created_at,
+  confirmed_at,
+  completed_at,
+  no_show_at,
   cancelled_at,
-  cancel_reason
+  cancel_reason,
+  cancelled_by,
+  cancel_source
 FROM bookings
-WHERE tstzrange(start_at, end_at, '[)') && tstzrange($1, $2, '[)')
+WHERE tstzrange(occupied_start_at, occupied_end_at, '[)') && tstzrange($1, $2, '[)')
+  AND (kind <> 'hold' OR hold_expires_at > now())
 ORDER BY start_at ASC;
 `
 
-const qCancelBooking = `
+// qUpdateBookingStatus меняет состояние, только если текущее входит в $2; время перехода пишется в свою колонку.
+const qUpdateBookingStatus = `
+UPDATE bookings
+SET
+  status = $3,
+  confirmed_at = CASE WHEN $3 = 'confirmed' THEN $4 ELSE confirmed_at END,
+  completed_at = CASE WHEN $3 = 'completed' THEN $4 ELSE completed_at END,
+  no_show_at = CASE WHEN $3 = 'no_show' THEN $4 ELSE no_show_at END,
+  cancelled_at = CASE WHEN $3 = 'cancelled' THEN $4 ELSE cancelled_at END,
+  cancel_reason = CASE WHEN $3 = 'cancelled' THEN $5 ELSE cancel_reason END,
+  cancelled_by = CASE WHEN $3 = 'cancelled' THEN $6 ELSE cancelled_by END,
+  cancel_source = CASE WHEN $3 = 'cancelled' THEN $7 ELSE cancel_source END
+WHERE id = $1 AND kind = 'client' AND status = ANY($2)
+RETURNING
+  id,
+  kind,
+  start_at,
+  end_at,
+  occupied_start_at,
+  occupied_end_at,
+  COALESCE(client_name, ''),
+  COALESCE(client_phone, ''),
+  client_id,
+  comment,
+  service_id,
+  service_name,
+  price_rub,
+  status,
+  pending_expires_at,
+  created_at,
+  confirmed_at,
+  completed_at,
+  no_show_at,
+  cancelled_at,
+  cancel_reason,
+  cancelled_by,
+  cancel_source;
+`
+
+const qListPendingBookings = `
+SELECT
+  id,
+  kind,
+  start_at,
+  end_at,
+  occupied_start_at,
+  occupied_end_at,
+  COALESCE(client_name, ''),
+  COALESCE(client_phone, ''),
+  client_id,
+  comment,
+  service_id,
+  service_name,
+  price_rub,
+  status,
+  pending_expires_at,
+  created_at,
+  confirmed_at,
+  completed_at,
+  no_show_at,
+  cancelled_at,
+  cancel_reason,
+  cancelled_by,
+  cancel_source
+FROM bookings
+WHERE kind = 'client' AND status = 'pending'
+ORDER BY start_at ASC;
+`
+
+const qExpirePendingBookings = `
 UPDATE bookings
 SET
   status = 'cancelled',
-  cancelled_at = CASE WHEN status = 'active' THEN $2 ELSE cancelled_at END,
-  cancel_reason = CASE WHEN status = 'active' THEN $3 ELSE cancel_reason END
+  cancelled_at = $1,
+  cancel_reason = $2,
+  cancel_source = 'system'
+WHERE status = 'pending' AND pending_expires_at <= $1;
+`
+
+const qDeleteBlock = `
+DELETE FROM bookings
+WHERE id = $1 AND kind = 'block';
+`
+
+// qUpsertClient находит клиента по телефону или заводит нового; для слитого дубликата — основного клиента.
+const qUpsertClient = `
+INSERT INTO clients (phone, name)
+VALUES ($1, $2)
+ON CONFLICT (phone) DO UPDATE SET updated_at = now()
+RETURNING COALESCE(merged_into, id);
+`
+
+// Удержание — строка kind = 'hold'; статус и confirmed_at заполняются, как у блокировки, ради общих ограничений.
+const qCreateSlotHold = `
+INSERT INTO bookings (kind, start_at, end_at, occupied_start_at, occupied_end_at, status, confirmed_at,
+                      hold_token_hash, hold_expires_at, hold_requester)
+VALUES ('hold', $1, $2, $3, $4, 'confirmed', now(), $5, $6, $7)
+RETURNING id, start_at, end_at, occupied_start_at, occupied_end_at, hold_expires_at, created_at;
+`
+
+// qLockHoldRequester сериализует удержания одного клиента, чтобы параллельные запросы не обошли лимиты.
+const qLockHoldRequester = `SELECT pg_advisory_xact_lock(hashtext('slot_hold:' || $1));`
+
+const qCountHoldRequests = `
+SELECT count(*), COALESCE(min(created_at), now())
+FROM slot_hold_requests
+WHERE requester = $1 AND created_at > $2;
+`
+
+const qCountActiveHolds = `
+SELECT count(*), COALESCE(min(hold_expires_at), now())
+FROM bookings
+WHERE kind = 'hold' AND hold_requester = $1 AND hold_expires_at > now();
+`
+
+const qInsertHoldRequest = `
+INSERT INTO slot_hold_requests (requester)
+VALUES ($1);
+`
+
+const qDeleteHoldRequestsBefore = `
+DELETE FROM slot_hold_requests
+WHERE created_at <= $1;
+`
+
+const qGetSlotHold = `
+SELECT id, start_at, end_at, occupied_start_at, occupied_end_at, hold_expires_at, created_at
+FROM bookings
+WHERE kind = 'hold' AND hold_token_hash = $1 AND hold_expires_at > $2;
+`
+
+const qDeleteSlotHold = `
+DELETE FROM bookings
+WHERE kind = 'hold' AND hold_token_hash = $1;
+`
+
+const qRedeemSlotHold = `
+DELETE FROM bookings
+WHERE kind = 'hold' AND hold_token_hash = $1 AND hold_expires_at > now();
+`
+
+// qReleaseExpiredHoldsInRange освобождает интервал от истёкших удержаний перед вставкой,
+// чтобы они не мешали bookings_no_overlap_active до прихода фоновой задачи.
+const qReleaseExpiredHoldsInRange = `
+DELETE FROM bookings
+WHERE kind = 'hold'
+  AND hold_expires_at <= now()
+  AND tstzrange(occupied_start_at, occupied_end_at, '[)') && tstzrange($1, $2, '[)');
+`
+
+const qDeleteExpiredSlotHolds = `
+DELETE FROM bookings
+WHERE kind = 'hold' AND hold_expires_at <= $1;
+`
+
+const qLockBookingForReschedule = `
+SELECT start_at, end_at
+FROM bookings
+WHERE id = $1 AND kind = 'client' AND status IN ('pending', 'confirmed')
+FOR UPDATE;
+`
+
+// qRescheduleBooking не продлевает срок заявки, но и не оставляет его позже нового начала сеанса.
+const qRescheduleBooking = `
+UPDATE bookings
+SET
+  start_at = $2,
+  end_at = $3,
+  occupied_start_at = $4,
+  occupied_end_at = $5,
+  pending_expires_at = CASE WHEN status = 'pending' THEN LEAST(pending_expires_at, $2) ELSE pending_expires_at END
 WHERE id = $1
 RETURNING
   id,
+  kind,
   start_at,
   end_at,
-  client_name,
-  client_phone,
+  occupied_start_at,
+  occupied_end_at,
+  COALESCE(client_name, ''),
+  COALESCE(client_phone, ''),
+  client_id,
   comment,
+  service_id,
+  service_name,
+  price_rub,
   status,
+  pending_expires_at,
   created_at,
+  confirmed_at,
+  completed_at,
+  no_show_at,
   cancelled_at,
-  cancel_reason;
+  cancel_reason,
+  cancelled_by,
+  cancel_source;
+`
+
+const qInsertBookingReschedule = `
+INSERT INTO booking_reschedules (booking_id, old_start_at, old_end_at, new_start_at, new_end_at, source, rescheduled_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+`
+
+const qListBookingReschedules = `
+SELECT id, booking_id, old_start_at, old_end_at, new_start_at, new_end_at, source, rescheduled_by, created_at
+FROM booking_reschedules
+WHERE booking_id = $1
+ORDER BY created_at ASC;
 `
 
 type rowScanner interface {
@@ -98,24 +368,43 @@ type rowScanner interface {
 
 func scanBooking(s rowScanner) (domain.Booking, error) {
 	var b domain.Booking
-	var status string
+	var kind, status string
+	var source *string
 
 	if err := s.Scan(
 		&b.ID,
+		&kind,
 		&b.StartAt,
 		&b.EndAt,
+		&b.OccupiedStartAt,
+		&b.OccupiedEndAt,
 		&b.ClientName,
 		&b.ClientPhone,
+		&b.ClientID,
 		&b.Comment,
+		&b.ServiceID,
+		&b.ServiceName,
+		&b.PriceRub,
 		&status,
+		&b.PendingExpiresAt,
 		&b.CreatedAt,
+		&b.ConfirmedAt,
+		&b.CompletedAt,
+		&b.NoShowAt,
 		&b.CancelledAt,
 		&b.CancelReason,
+		&b.CancelledBy,
+		&source,
 	); err != nil {
 		return domain.Booking{}, err
 	}
 
+	b.Kind = domain.BookingKind(kind)
 	b.Status = domain.BookingStatus(status)
+	if source != nil {
+		cs := domain.BookingActor(*source)
+		b.CancelSource = &cs
+	}
 	return b, nil
 }
 
@@ -124,12 +413,69 @@ func (r *BookingRepository) Create(ctx context.Context, p repository.CreateBooki
 		return domain.Booking{}, fmt.Errorf("postgres: booking repo: pool is nil")
 	}
 
-	row := r.pool.QueryRow(ctx, qCreateBooking,
+	kind := p.Kind
+	if kind == "" {
+		kind = domain.BookingKindClient
+	}
+	status := p.Status
+	if status == "" {
+		status = domain.BookingStatusConfirmed
+	}
+	occStart, occEnd := p.OccupiedStartAt, p.OccupiedEndAt
+	if occStart.IsZero() {
+		occStart = p.StartAt
+	}
+	if occEnd.IsZero() {
+		occEnd = p.EndAt
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := checkDateOpen(ctx, tx, p.StudioDate); err != nil {
+		return domain.Booking{}, err
+	}
+	if p.HoldTokenHash != "" {
+		tag, err := tx.Exec(ctx, qRedeemSlotHold, p.HoldTokenHash)
+		if err != nil {
+			return domain.Booking{}, mapPgError(err)
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.Booking{}, domain.ErrNotFound
+		}
+	}
+	if _, err := tx.Exec(ctx, qReleaseExpiredHoldsInRange, occStart, occEnd); err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
+
+	var clientID *uuid.UUID
+	if kind == domain.BookingKindClient {
+		var id uuid.UUID
+		if err := tx.QueryRow(ctx, qUpsertClient, p.ClientPhone, p.ClientName).Scan(&id); err != nil {
+			return domain.Booking{}, mapPgError(err)
+		}
+		clientID = &id
+	}
+
+	row := tx.QueryRow(ctx, qCreateBooking,
+		string(kind),
 		p.StartAt,
 		p.EndAt,
-		p.ClientName,
-		p.ClientPhone,
+		occStart,
+		occEnd,
+		nullIfEmpty(p.ClientName),
+		nullIfEmpty(p.ClientPhone),
 		p.Comment,
+		p.ServiceID,
+		p.ServiceName,
+		p.PriceRub,
+		nullIfEmpty(p.ManageTokenHash),
+		string(status),
+		p.PendingExpiresAt,
+		clientID,
 	)
 
 	b, err := scanBooking(row)
@@ -137,6 +483,9 @@ func (r *BookingRepository) Create(ctx context.Context, p repository.CreateBooki
 		return domain.Booking{}, mapPgError(err)
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
 	return b, nil
 }
 
@@ -155,6 +504,37 @@ func (r *BookingRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.B
 	return b, nil
 }
 
+func (r *BookingRepository) GetByManageToken(ctx context.Context, id uuid.UUID, tokenHash string) (domain.Booking, error) {
+	if r.pool == nil {
+		return domain.Booking{}, fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	row := r.pool.QueryRow(ctx, qGetBookingByManageToken, id, tokenHash)
+
+	b, err := scanBooking(row)
+	if err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
+
+	return b, nil
+}
+
+func (r *BookingRepository) SetManageTokenHash(ctx context.Context, id uuid.UUID, tokenHash string) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qSetBookingManageToken, id, tokenHash)
+	if err != nil {
+		return mapPgError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *BookingRepository) ListByRange(ctx context.Context, p repository.ListBookingsByRangeParams) ([]domain.Booking, error) {
 	if r.pool == nil {
 		return nil, fmt.Errorf("postgres: booking repo: pool is nil")
@@ -182,12 +562,22 @@ func (r *BookingRepository) ListByRange(ctx context.Context, p repository.ListBo
 	return out, nil
 }
 
-func (r *BookingRepository) Cancel(ctx context.Context, p repository.CancelBookingParams) (domain.Booking, error) {
+func (r *BookingRepository) UpdateStatus(ctx context.Context, p repository.UpdateBookingStatusParams) (domain.Booking, error) {
 	if r.pool == nil {
 		return domain.Booking{}, fmt.Errorf("postgres: booking repo: pool is nil")
 	}
 
-	row := r.pool.QueryRow(ctx, qCancelBooking, p.ID, p.NowUTC, p.Reason)
+	from := make([]string, 0, len(p.From))
+	for _, st := range p.From {
+		from = append(from, string(st))
+	}
+	var source *string
+	if p.Source != "" {
+		v := string(p.Source)
+		source = &v
+	}
+
+	row := r.pool.QueryRow(ctx, qUpdateBookingStatus, p.ID, from, string(p.To), p.NowUTC, p.Reason, p.CancelledBy, source)
 
 	b, err := scanBooking(row)
 	if err != nil {
@@ -200,6 +590,279 @@ func (r *BookingRepository) Cancel(ctx context.Context, p repository.CancelBooki
 	return b, nil
 }
 
+func (r *BookingRepository) ListPending(ctx context.Context) ([]domain.Booking, error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	rows, err := r.pool.Query(ctx, qListPendingBookings)
+	if err != nil {
+		return nil, mapPgError(err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.Booking, 0, 16)
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		out = append(out, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapPgError(err)
+	}
+
+	return out, nil
+}
+
+func (r *BookingRepository) ExpirePending(ctx context.Context, nowUTC time.Time, reason string) (int64, error) {
+	if r.pool == nil {
+		return 0, fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qExpirePendingBookings, nowUTC, reason)
+	if err != nil {
+		return 0, mapPgError(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *BookingRepository) DeleteBlock(ctx context.Context, id uuid.UUID) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qDeleteBlock, id)
+	if err != nil {
+		return mapPgError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *BookingRepository) CreateHold(ctx context.Context, p repository.CreateSlotHoldParams) (domain.SlotHold, error) {
+	if r.pool == nil {
+		return domain.SlotHold{}, fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.SlotHold{}, mapPgError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := checkDateOpen(ctx, tx, p.StudioDate); err != nil {
+		return domain.SlotHold{}, err
+	}
+	if p.ReplaceTokenHash != "" {
+		if _, err := tx.Exec(ctx, qDeleteSlotHold, p.ReplaceTokenHash); err != nil {
+			return domain.SlotHold{}, mapPgError(err)
+		}
+	}
+	if err := checkHoldLimits(ctx, tx, p); err != nil {
+		return domain.SlotHold{}, err
+	}
+	if _, err := tx.Exec(ctx, qReleaseExpiredHoldsInRange, p.OccupiedStartAt, p.OccupiedEndAt); err != nil {
+		return domain.SlotHold{}, mapPgError(err)
+	}
+
+	h, err := scanSlotHold(tx.QueryRow(ctx, qCreateSlotHold,
+		p.StartAt,
+		p.EndAt,
+		p.OccupiedStartAt,
+		p.OccupiedEndAt,
+		p.TokenHash,
+		p.ExpiresAt,
+		nullIfEmpty(p.Requester),
+	))
+	if err != nil {
+		return domain.SlotHold{}, mapPgError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.SlotHold{}, mapPgError(err)
+	}
+	return h, nil
+}
+
+func (r *BookingRepository) GetHold(ctx context.Context, tokenHash string, nowUTC time.Time) (domain.SlotHold, error) {
+	if r.pool == nil {
+		return domain.SlotHold{}, fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	h, err := scanSlotHold(r.pool.QueryRow(ctx, qGetSlotHold, tokenHash, nowUTC))
+	if err != nil {
+		return domain.SlotHold{}, mapPgError(err)
+	}
+
+	return h, nil
+}
+
+func (r *BookingRepository) DeleteExpiredHolds(ctx context.Context, nowUTC time.Time) (int64, error) {
+	if r.pool == nil {
+		return 0, fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qDeleteExpiredSlotHolds, nowUTC)
+	if err != nil {
+		return 0, mapPgError(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *BookingRepository) DeleteHoldRequestsBefore(ctx context.Context, before time.Time) (int64, error) {
+	if r.pool == nil {
+		return 0, fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qDeleteHoldRequestsBefore, before)
+	if err != nil {
+		return 0, mapPgError(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// checkHoldLimits проверяет лимиты удержаний клиента и учитывает текущий запрос; прежнее удержание
+// (ReplaceTokenHash) к этому моменту уже снято и в число действующих не входит.
+func checkHoldLimits(ctx context.Context, tx pgx.Tx, p repository.CreateSlotHoldParams) error {
+	if p.Requester == "" {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, qLockHoldRequester, p.Requester); err != nil {
+		return mapPgError(err)
+	}
+
+	var (
+		n      int
+		oldest time.Time
+	)
+	if err := tx.QueryRow(ctx, qCountHoldRequests, p.Requester, p.RateWindowStart).Scan(&n, &oldest); err != nil {
+		return mapPgError(err)
+	}
+	if n >= p.MaxRequests {
+		return domain.TooManyRequestsError{RetryAfter: oldest.Sub(p.RateWindowStart)}
+	}
+
+	var nextExpiry time.Time
+	if err := tx.QueryRow(ctx, qCountActiveHolds, p.Requester).Scan(&n, &nextExpiry); err != nil {
+		return mapPgError(err)
+	}
+	if n >= p.MaxActive {
+		return domain.TooManyRequestsError{RetryAfter: time.Until(nextExpiry)}
+	}
+
+	if _, err := tx.Exec(ctx, qInsertHoldRequest, p.Requester); err != nil {
+		return mapPgError(err)
+	}
+	return nil
+}
+
+func scanSlotHold(s rowScanner) (domain.SlotHold, error) {
+	var h domain.SlotHold
+	err := s.Scan(&h.ID, &h.StartAt, &h.EndAt, &h.OccupiedStartAt, &h.OccupiedEndAt, &h.ExpiresAt, &h.CreatedAt)
+	return h, err
+}
+
+func (r *BookingRepository) Reschedule(ctx context.Context, p repository.RescheduleBookingParams) (domain.Booking, error) {
+	if r.pool == nil {
+		return domain.Booking{}, fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := checkDateOpen(ctx, tx, p.StudioDate); err != nil {
+		return domain.Booking{}, err
+	}
+
+	var oldStart, oldEnd time.Time
+	if err := tx.QueryRow(ctx, qLockBookingForReschedule, p.ID).Scan(&oldStart, &oldEnd); err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
+	if _, err := tx.Exec(ctx, qReleaseExpiredHoldsInRange, p.OccupiedStartAt, p.OccupiedEndAt); err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
+
+	b, err := scanBooking(tx.QueryRow(ctx, qRescheduleBooking, p.ID, p.StartAt, p.EndAt, p.OccupiedStartAt, p.OccupiedEndAt))
+	if err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
+
+	if _, err := tx.Exec(ctx, qInsertBookingReschedule,
+		p.ID,
+		oldStart,
+		oldEnd,
+		p.StartAt,
+		p.EndAt,
+		string(p.Source),
+		p.RescheduledBy,
+	); err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Booking{}, mapPgError(err)
+	}
+	return b, nil
+}
+
+func (r *BookingRepository) ListReschedules(ctx context.Context, bookingID uuid.UUID) ([]domain.BookingReschedule, error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("postgres: booking repo: pool is nil")
+	}
+
+	rows, err := r.pool.Query(ctx, qListBookingReschedules, bookingID)
+	if err != nil {
+		return nil, mapPgError(err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.BookingReschedule, 0, 4)
+	for rows.Next() {
+		var rs domain.BookingReschedule
+		var source string
+		if err := rows.Scan(
+			&rs.ID,
+			&rs.BookingID,
+			&rs.OldStartAt,
+			&rs.OldEndAt,
+			&rs.NewStartAt,
+			&rs.NewEndAt,
+			&source,
+			&rs.RescheduledBy,
+			&rs.CreatedAt,
+		); err != nil {
+			return nil, mapPgError(err)
+		}
+		rs.Source = domain.BookingActor(source)
+		out = append(out, rs)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapPgError(err)
+	}
+
+	return out, nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 func errorsIsNoRows(err error) bool {
 	return errors.Is(err, pgx.ErrNoRows)
 }
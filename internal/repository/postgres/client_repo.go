@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type ClientRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewClientRepository(pool *pgxpool.Pool) *ClientRepository {
+	return &ClientRepository{pool: pool}
+}
+
+var _ repository.ClientRepository = (*ClientRepository)(nil)
+
+// qClientStats — клиент со статистикой по записям; условие WHERE дописывается в запросах ниже.
+const qClientStats = `
+SELECT
+  c.id,
+  c.name,
+  c.phone,
+  count(b.id) FILTER (WHERE b.status <> 'cancelled'),
+  count(b.id) FILTER (WHERE b.status = 'completed'),
+  count(b.id) FILTER (WHERE b.status = 'no_show'),
+  max(b.start_at) FILTER (WHERE b.status = 'completed'),
+  COALESCE(sum(b.price_rub) FILTER (WHERE b.status = 'completed'), 0),
+  c.created_at,
+  c.updated_at
+FROM clients c
+LEFT JOIN bookings b ON b.client_id = c.id
+`
+
+const qListClients = qClientStats + `
+WHERE c.merged_into IS NULL
+  AND (($1 = '' AND $2 = '') OR ($1 <> '' AND c.name ILIKE '%' || $1 || '%') OR ($2 <> '' AND c.phone LIKE '%' || $2 || '%'))
+GROUP BY c.id
+ORDER BY max(b.start_at) FILTER (WHERE b.status = 'completed') DESC NULLS LAST, c.name ASC, c.id ASC
+LIMIT $3 OFFSET $4;
+`
+
+const qGetClientByID = qClientStats + `
+WHERE c.id = $1 AND c.merged_into IS NULL
+GROUP BY c.id;
+`
+
+const qLockClientsForMerge = `
+SELECT id
+FROM clients
+WHERE id = ANY($1) AND merged_into IS NULL
+FOR UPDATE;
+`
+
+const qMoveClientBookings = `
+UPDATE bookings
+SET client_id = $1
+WHERE client_id = ANY($2);
+`
+
+// Дубликаты, ранее слитые в сливаемых клиентов, перенаправляются сразу на target — цепочек merged_into не бывает.
+const qMarkClientsMerged = `
+UPDATE clients
+SET merged_into = $1,
+    updated_at = now()
+WHERE id = ANY($2) OR merged_into = ANY($2);
+`
+
+func scanClient(s rowScanner) (domain.Client, error) {
+	var c domain.Client
+	err := s.Scan(
+		&c.ID,
+		&c.Name,
+		&c.Phone,
+		&c.BookingsCount,
+		&c.VisitsCount,
+		&c.NoShowCount,
+		&c.LastVisitAt,
+		&c.TotalSpentRub,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	return c, err
+}
+
+func (r *ClientRepository) List(ctx context.Context, p repository.ListClientsParams) ([]domain.Client, error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("postgres: client repo: pool is nil")
+	}
+
+	rows, err := r.pool.Query(ctx, qListClients, p.Query, p.PhoneDigits, p.Limit, p.Offset)
+	if err != nil {
+		return nil, mapPgError(err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.Client, 0, p.Limit)
+	for rows.Next() {
+		c, err := scanClient(rows)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		out = append(out, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapPgError(err)
+	}
+
+	return out, nil
+}
+
+func (r *ClientRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.Client, error) {
+	if r.pool == nil {
+		return domain.Client{}, fmt.Errorf("postgres: client repo: pool is nil")
+	}
+
+	c, err := scanClient(r.pool.QueryRow(ctx, qGetClientByID, id))
+	if err != nil {
+		return domain.Client{}, mapPgError(err)
+	}
+
+	return c, nil
+}
+
+func (r *ClientRepository) Merge(ctx context.Context, targetID uuid.UUID, duplicateIDs []uuid.UUID) (domain.Client, error) {
+	if r.pool == nil {
+		return domain.Client{}, fmt.Errorf("postgres: client repo: pool is nil")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Client{}, mapPgError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	ids := append([]uuid.UUID{targetID}, duplicateIDs...)
+	rows, err := tx.Query(ctx, qLockClientsForMerge, ids)
+	if err != nil {
+		return domain.Client{}, mapPgError(err)
+	}
+	locked := 0
+	for rows.Next() {
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.Client{}, mapPgError(err)
+	}
+	if locked != len(ids) {
+		return domain.Client{}, domain.ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, qMoveClientBookings, targetID, duplicateIDs); err != nil {
+		return domain.Client{}, mapPgError(err)
+	}
+	if _, err := tx.Exec(ctx, qMarkClientsMerged, targetID, duplicateIDs); err != nil {
+		return domain.Client{}, mapPgError(err)
+	}
+
+	c, err := scanClient(tx.QueryRow(ctx, qGetClientByID, targetID))
+	if err != nil {
+		return domain.Client{}, mapPgError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Client{}, mapPgError(err)
+	}
+	return c, nil
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type ClosureRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewClosureRepository(pool *pgxpool.Pool) *ClosureRepository {
+	return &ClosureRepository{pool: pool}
+}
+
+var _ repository.ClosureRepository = (*ClosureRepository)(nil)
+
+// Закрытия и записи сериализуются транзакционной advisory-блокировкой: создание закрытия берёт её
+// эксклюзивно, создание и перенос записей — разделяемой, поэтому проверка «день не закрыт» и
+// проверка «в днях нет записей» не могут разойтись.
+const qLockClosuresExclusive = `SELECT pg_advisory_xact_lock(hashtext('closures'));`
+
+const qLockClosuresShared = `SELECT pg_advisory_xact_lock_shared(hashtext('closures'));`
+
+const qIsDateClosed = `
+SELECT EXISTS (
+  SELECT 1
+  FROM closures
+  WHERE $1::date BETWEEN start_date AND end_date
+);
+`
+
+const qListActiveClientBookingsByRange = `
+SELECT
+  id,
+  kind,
+  start_at,
+  end_at,
+  occupied_start_at,
+  occupied_end_at,
+  COALESCE(client_name, ''),
+  COALESCE(client_phone, ''),
+  client_id,
+  comment,
+  service_id,
+  service_name,
+  price_rub,
+  status,
+  pending_expires_at,
+  created_at,
+  confirmed_at,
+  completed_at,
+  no_show_at,
+  cancelled_at,
+  cancel_reason,
+  cancelled_by,
+  cancel_source
+FROM bookings
+WHERE tstzrange(occupied_start_at, occupied_end_at, '[)') && tstzrange($1, $2, '[)')
+  AND kind = 'client'
+  AND status IN ('pending', 'confirmed')
+ORDER BY start_at ASC;
+`
+
+const qCreateClosure = `
+INSERT INTO closures (start_date, end_date, reason)
+VALUES ($1, $2, $3)
+RETURNING
+  id,
+  start_date,
+  end_date,
+  reason,
+  created_at;
+`
+
+const qListClosuresByRange = `
+SELECT
+  id,
+  start_date,
+  end_date,
+  reason,
+  created_at
+FROM closures
+WHERE daterange(start_date, end_date, '[]') && daterange($1::date, $2::date, '[]')
+ORDER BY start_date ASC, created_at ASC;
+`
+
+const qDeleteClosure = `
+DELETE FROM closures
+WHERE id = $1;
+`
+
+func scanClosure(s rowScanner) (domain.Closure, error) {
+	var c domain.Closure
+
+	if err := s.Scan(
+		&c.ID,
+		&c.StartDate,
+		&c.EndDate,
+		&c.Reason,
+		&c.CreatedAt,
+	); err != nil {
+		return domain.Closure{}, err
+	}
+
+	return c, nil
+}
+
+func (r *ClosureRepository) Create(ctx context.Context, p repository.CreateClosureParams) (domain.Closure, error) {
+	if r.pool == nil {
+		return domain.Closure{}, fmt.Errorf("postgres: closure repo: pool is nil")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Closure{}, mapPgError(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, qLockClosuresExclusive); err != nil {
+		return domain.Closure{}, mapPgError(err)
+	}
+
+	rows, err := tx.Query(ctx, qListActiveClientBookingsByRange, p.BookingsFrom, p.BookingsTo)
+	if err != nil {
+		return domain.Closure{}, mapPgError(err)
+	}
+	var conflicts []domain.Booking
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			rows.Close()
+			return domain.Closure{}, mapPgError(err)
+		}
+		conflicts = append(conflicts, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.Closure{}, mapPgError(err)
+	}
+	if len(conflicts) > 0 {
+		return domain.Closure{}, domain.BookingsConflictError{Bookings: conflicts}
+	}
+
+	c, err := scanClosure(tx.QueryRow(ctx, qCreateClosure, p.StartDate, p.EndDate, p.Reason))
+	if err != nil {
+		return domain.Closure{}, mapPgError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Closure{}, mapPgError(err)
+	}
+	return c, nil
+}
+
+func (r *ClosureRepository) ListByRange(ctx context.Context, p repository.ListClosuresByRangeParams) ([]domain.Closure, error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("postgres: closure repo: pool is nil")
+	}
+
+	rows, err := r.pool.Query(ctx, qListClosuresByRange, p.FromDate, p.ToDate)
+	if err != nil {
+		return nil, mapPgError(err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.Closure, 0, 8)
+	for rows.Next() {
+		c, err := scanClosure(rows)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		out = append(out, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapPgError(err)
+	}
+
+	return out, nil
+}
+
+func (r *ClosureRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: closure repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qDeleteClosure, id)
+	if err != nil {
+		return mapPgError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// checkDateOpen берёт разделяемую блокировку закрытий до конца транзакции и проверяет, что день
+// студии не закрыт; нулевая дата — без проверки.
+func checkDateOpen(ctx context.Context, tx pgx.Tx, date time.Time) error {
+	if date.IsZero() {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, qLockClosuresShared); err != nil {
+		return mapPgError(err)
+	}
+
+	var closed bool
+	if err := tx.QueryRow(ctx, qIsDateClosed, date).Scan(&closed); err != nil {
+		return mapPgError(err)
+	}
+	if closed {
+		return domain.ErrStudioClosed
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 const (
 	sqlStateExclusionViolation = "23P01"
+	sqlStateUniqueViolation    = "23505"
 )
 
 func mapPgError(err error) error {
@@ -27,6 +28,8 @@ func mapPgError(err error) error {
 	if errors.As(err, &pgErr) {
 		switch pgErr.Code {
 		case sqlStateExclusionViolation:
+			return domain.ErrTimeTaken
+		case sqlStateUniqueViolation:
 			return domain.ErrConflict
 		}
 	}
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"photannie/internal/domain"
+)
+
+func TestMapPgError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		want          error
+		wantTimeTaken bool
+	}{
+		{"no rows", pgx.ErrNoRows, domain.ErrNotFound, false},
+		{"exclusion violation", &pgconn.PgError{Code: sqlStateExclusionViolation}, domain.ErrConflict, true},
+		{"unique violation", &pgconn.PgError{Code: sqlStateUniqueViolation}, domain.ErrConflict, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapPgError(tt.err)
+			if !errors.Is(got, tt.want) {
+				t.Errorf("mapPgError = %v, want %v", got, tt.want)
+			}
+			if errors.Is(got, domain.ErrTimeTaken) != tt.wantTimeTaken {
+				t.Errorf("mapPgError = %v, ErrTimeTaken = %v, want %v", got, !tt.wantTimeTaken, tt.wantTimeTaken)
+			}
+		})
+	}
+}
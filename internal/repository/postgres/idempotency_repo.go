@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type IdempotencyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(pool *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{pool: pool}
+}
+
+var _ repository.IdempotencyRepository = (*IdempotencyRepository)(nil)
+
+// Просроченный ключ или зависший (не завершённый до StaleBefore) запрос перезанимается новым.
+const qReserveIdempotencyKey = `
+INSERT INTO idempotency_keys (scope, key, request_hash, created_at, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (scope, key) DO UPDATE
+SET
+  request_hash = EXCLUDED.request_hash,
+  status_code = NULL,
+  response_body = NULL,
+  created_at = EXCLUDED.created_at,
+  completed_at = NULL,
+  expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= $4
+   OR (idempotency_keys.completed_at IS NULL AND idempotency_keys.created_at <= $6)
+RETURNING
+  scope,
+  key,
+  request_hash,
+  status_code,
+  response_body,
+  created_at,
+  completed_at,
+  expires_at;
+`
+
+const qGetIdempotencyKey = `
+SELECT
+  scope,
+  key,
+  request_hash,
+  status_code,
+  response_body,
+  created_at,
+  completed_at,
+  expires_at
+FROM idempotency_keys
+WHERE scope = $1 AND key = $2;
+`
+
+const qCompleteIdempotencyKey = `
+UPDATE idempotency_keys
+SET
+  status_code = $3,
+  response_body = $4,
+  completed_at = $5
+WHERE scope = $1 AND key = $2 AND completed_at IS NULL;
+`
+
+const qReleaseIdempotencyKey = `
+DELETE FROM idempotency_keys
+WHERE scope = $1 AND key = $2 AND completed_at IS NULL;
+`
+
+const qDeleteExpiredIdempotencyKeys = `
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1;
+`
+
+func scanIdempotencyRecord(s rowScanner) (domain.IdempotencyRecord, error) {
+	var rec domain.IdempotencyRecord
+	var status *int
+
+	if err := s.Scan(
+		&rec.Scope,
+		&rec.Key,
+		&rec.RequestHash,
+		&status,
+		&rec.ResponseBody,
+		&rec.CreatedAt,
+		&rec.CompletedAt,
+		&rec.ExpiresAt,
+	); err != nil {
+		return domain.IdempotencyRecord{}, err
+	}
+
+	if status != nil {
+		rec.StatusCode = *status
+	}
+	return rec, nil
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, p repository.ReserveIdempotencyKeyParams) (domain.IdempotencyRecord, bool, error) {
+	if r.pool == nil {
+		return domain.IdempotencyRecord{}, false, fmt.Errorf("postgres: idempotency repo: pool is nil")
+	}
+
+	row := r.pool.QueryRow(ctx, qReserveIdempotencyKey,
+		p.Scope,
+		p.Key,
+		p.RequestHash,
+		p.NowUTC,
+		p.ExpiresAt,
+		p.StaleBefore,
+	)
+
+	rec, err := scanIdempotencyRecord(row)
+	if err == nil {
+		return rec, true, nil
+	}
+	if !errorsIsNoRows(err) {
+		return domain.IdempotencyRecord{}, false, mapPgError(err)
+	}
+
+	row = r.pool.QueryRow(ctx, qGetIdempotencyKey, p.Scope, p.Key)
+
+	rec, err = scanIdempotencyRecord(row)
+	if err != nil {
+		return domain.IdempotencyRecord{}, false, mapPgError(err)
+	}
+
+	return rec, false, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, p repository.CompleteIdempotencyKeyParams) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: idempotency repo: pool is nil")
+	}
+
+	if _, err := r.pool.Exec(ctx, qCompleteIdempotencyKey,
+		p.Scope,
+		p.Key,
+		p.StatusCode,
+		p.ResponseBody,
+		p.NowUTC,
+	); err != nil {
+		return mapPgError(err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, scope, key string) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: idempotency repo: pool is nil")
+	}
+
+	if _, err := r.pool.Exec(ctx, qReleaseIdempotencyKey, scope, key); err != nil {
+		return mapPgError(err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, nowUTC time.Time) (int64, error) {
+	if r.pool == nil {
+		return 0, fmt.Errorf("postgres: idempotency repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qDeleteExpiredIdempotencyKeys, nowUTC)
+	if err != nil {
+		return 0, mapPgError(err)
+	}
+
+	return tag.RowsAffected(), nil
+}
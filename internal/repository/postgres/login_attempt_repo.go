@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type LoginAttemptRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewLoginAttemptRepository(pool *pgxpool.Pool) *LoginAttemptRepository {
+	return &LoginAttemptRepository{pool: pool}
+}
+
+var _ repository.LoginAttemptRepository = (*LoginAttemptRepository)(nil)
+
+const qLoginLockedUntil = `
+SELECT COALESCE(max(locked_until), 'epoch'::timestamptz)
+FROM login_attempts
+WHERE locked_until > $1
+  AND ((scope = 'ip' AND key = $2) OR (scope = 'username' AND key = $3));
+`
+
+const qRegisterLoginFailure = `
+INSERT INTO login_attempts (scope, key, failures, last_failure_at)
+VALUES ($1, $2, 1, $3)
+ON CONFLICT (scope, key) DO UPDATE
+SET
+  failures = CASE
+    WHEN GREATEST(login_attempts.last_failure_at, COALESCE(login_attempts.locked_until, login_attempts.last_failure_at)) < $4
+      THEN 1
+    ELSE login_attempts.failures + 1
+  END,
+  last_failure_at = EXCLUDED.last_failure_at
+RETURNING failures;
+`
+
+const qLockLogin = `
+WITH a AS (
+  UPDATE login_attempts
+  SET locked_until = $4
+  WHERE scope = $1 AND key = $2
+)
+INSERT INTO login_lockouts (scope, key, failures, locked_until)
+VALUES ($1, $2, $3, $4)
+RETURNING id, scope, key, failures, locked_until, created_at;
+`
+
+const qResetLoginAttempts = `
+DELETE FROM login_attempts
+WHERE scope = $1 AND key = $2;
+`
+
+const qDeleteStaleLoginAttempts = `
+DELETE FROM login_attempts
+WHERE last_failure_at < $2
+  AND (locked_until IS NULL OR locked_until <= $1);
+`
+
+const qListLoginLockouts = `
+SELECT id, scope, key, failures, locked_until, created_at
+FROM login_lockouts
+ORDER BY created_at DESC
+LIMIT $1;
+`
+
+func scanLoginLockout(s rowScanner) (domain.LoginLockout, error) {
+	var l domain.LoginLockout
+	var scope string
+	if err := s.Scan(
+		&l.ID,
+		&scope,
+		&l.Key,
+		&l.Failures,
+		&l.LockedUntil,
+		&l.CreatedAt,
+	); err != nil {
+		return domain.LoginLockout{}, err
+	}
+	l.Scope = domain.LoginScope(scope)
+	return l, nil
+}
+
+func (r *LoginAttemptRepository) LockedUntil(ctx context.Context, nowUTC time.Time, ip, username string) (time.Time, error) {
+	if r.pool == nil {
+		return time.Time{}, fmt.Errorf("postgres: login attempt repo: pool is nil")
+	}
+
+	var until time.Time
+	if err := r.pool.QueryRow(ctx, qLoginLockedUntil, nowUTC, ip, username).Scan(&until); err != nil {
+		return time.Time{}, mapPgError(err)
+	}
+	if !until.After(nowUTC) {
+		return time.Time{}, nil
+	}
+	return until, nil
+}
+
+func (r *LoginAttemptRepository) RegisterFailure(ctx context.Context, p repository.RegisterLoginFailureParams) (int, error) {
+	if r.pool == nil {
+		return 0, fmt.Errorf("postgres: login attempt repo: pool is nil")
+	}
+
+	var failures int
+	if err := r.pool.QueryRow(ctx, qRegisterLoginFailure, string(p.Scope), p.Key, p.NowUTC, p.WindowStart).Scan(&failures); err != nil {
+		return 0, mapPgError(err)
+	}
+	return failures, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, p repository.LockLoginParams) (domain.LoginLockout, error) {
+	if r.pool == nil {
+		return domain.LoginLockout{}, fmt.Errorf("postgres: login attempt repo: pool is nil")
+	}
+
+	row := r.pool.QueryRow(ctx, qLockLogin, string(p.Scope), p.Key, p.Failures, p.LockedUntil)
+
+	l, err := scanLoginLockout(row)
+	if err != nil {
+		return domain.LoginLockout{}, mapPgError(err)
+	}
+	return l, nil
+}
+
+func (r *LoginAttemptRepository) Reset(ctx context.Context, scope domain.LoginScope, key string) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: login attempt repo: pool is nil")
+	}
+
+	if _, err := r.pool.Exec(ctx, qResetLoginAttempts, string(scope), key); err != nil {
+		return mapPgError(err)
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) DeleteStale(ctx context.Context, nowUTC, before time.Time) (int64, error) {
+	if r.pool == nil {
+		return 0, fmt.Errorf("postgres: login attempt repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qDeleteStaleLoginAttempts, nowUTC, before)
+	if err != nil {
+		return 0, mapPgError(err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *LoginAttemptRepository) ListLockouts(ctx context.Context, limit int) ([]domain.LoginLockout, error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("postgres: login attempt repo: pool is nil")
+	}
+
+	rows, err := r.pool.Query(ctx, qListLoginLockouts, limit)
+	if err != nil {
+		return nil, mapPgError(err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.LoginLockout, 0, limit)
+	for rows.Next() {
+		l, err := scanLoginLockout(rows)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		out = append(out, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapPgError(err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type StudioServiceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStudioServiceRepository(pool *pgxpool.Pool) *StudioServiceRepository {
+	return &StudioServiceRepository{pool: pool}
+}
+
+var _ repository.StudioServiceRepository = (*StudioServiceRepository)(nil)
+
+const qCreateStudioService = `
+INSERT INTO services (name, description, duration_minutes, price_rub, is_active, requires_approval)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING
+  id,
+  name,
+  description,
+  duration_minutes,
+  price_rub,
+  is_active,
+  requires_approval,
+  created_at,
+  updated_at;
+`
+
+const qGetStudioServiceByID = `
+SELECT
+  id,
+  name,
+  description,
+  duration_minutes,
+  price_rub,
+  is_active,
+  requires_approval,
+  created_at,
+  updated_at
+FROM services
+WHERE id = $1;
+`
+
+const qListStudioServices = `
+SELECT
+  id,
+  name,
+  description,
+  duration_minutes,
+  price_rub,
+  is_active,
+  requires_approval,
+  created_at,
+  updated_at
+FROM services
+WHERE is_active OR NOT $1
+ORDER BY name ASC, created_at ASC;
+`
+
+const qUpdateStudioService = `
+UPDATE services
+SET
+  name = $2,
+  description = $3,
+  duration_minutes = $4,
+  price_rub = $5,
+  is_active = $6,
+  requires_approval = $7,
+  updated_at = now()
+WHERE id = $1
+RETURNING
+  id,
+  name,
+  description,
+  duration_minutes,
+  price_rub,
+  is_active,
+  requires_approval,
+  created_at,
+  updated_at;
+`
+
+const qDeleteStudioService = `
+DELETE FROM services
+WHERE id = $1;
+`
+
+func scanStudioService(s rowScanner) (domain.StudioService, error) {
+	var svc domain.StudioService
+
+	if err := s.Scan(
+		&svc.ID,
+		&svc.Name,
+		&svc.Description,
+		&svc.DurationMinutes,
+		&svc.PriceRub,
+		&svc.IsActive,
+		&svc.RequiresApproval,
+		&svc.CreatedAt,
+		&svc.UpdatedAt,
+	); err != nil {
+		return domain.StudioService{}, err
+	}
+
+	return svc, nil
+}
+
+func (r *StudioServiceRepository) Create(ctx context.Context, p repository.StudioServiceParams) (domain.StudioService, error) {
+	if r.pool == nil {
+		return domain.StudioService{}, fmt.Errorf("postgres: studio service repo: pool is nil")
+	}
+
+	row := r.pool.QueryRow(ctx, qCreateStudioService,
+		p.Name,
+		p.Description,
+		p.DurationMinutes,
+		p.PriceRub,
+		p.IsActive,
+		p.RequiresApproval,
+	)
+
+	svc, err := scanStudioService(row)
+	if err != nil {
+		return domain.StudioService{}, mapPgError(err)
+	}
+
+	return svc, nil
+}
+
+func (r *StudioServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.StudioService, error) {
+	if r.pool == nil {
+		return domain.StudioService{}, fmt.Errorf("postgres: studio service repo: pool is nil")
+	}
+
+	row := r.pool.QueryRow(ctx, qGetStudioServiceByID, id)
+
+	svc, err := scanStudioService(row)
+	if err != nil {
+		return domain.StudioService{}, mapPgError(err)
+	}
+
+	return svc, nil
+}
+
+func (r *StudioServiceRepository) List(ctx context.Context, activeOnly bool) ([]domain.StudioService, error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("postgres: studio service repo: pool is nil")
+	}
+
+	rows, err := r.pool.Query(ctx, qListStudioServices, activeOnly)
+	if err != nil {
+		return nil, mapPgError(err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.StudioService, 0, 16)
+	for rows.Next() {
+		svc, err := scanStudioService(rows)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		out = append(out, svc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapPgError(err)
+	}
+
+	return out, nil
+}
+
+func (r *StudioServiceRepository) Update(ctx context.Context, id uuid.UUID, p repository.StudioServiceParams) (domain.StudioService, error) {
+	if r.pool == nil {
+		return domain.StudioService{}, fmt.Errorf("postgres: studio service repo: pool is nil")
+	}
+
+	row := r.pool.QueryRow(ctx, qUpdateStudioService,
+		id,
+		p.Name,
+		p.Description,
+		p.DurationMinutes,
+		p.PriceRub,
+		p.IsActive,
+		p.RequiresApproval,
+	)
+
+	svc, err := scanStudioService(row)
+	if err != nil {
+		return domain.StudioService{}, mapPgError(err)
+	}
+
+	return svc, nil
+}
+
+func (r *StudioServiceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if r.pool == nil {
+		return fmt.Errorf("postgres: studio service repo: pool is nil")
+	}
+
+	tag, err := r.pool.Exec(ctx, qDeleteStudioService, id)
+	if err != nil {
+		return mapPgError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/totp"
+)
+
+const (
+	totpIssuer = "photannie"
+	totpSkew   = 1 // допускаем ±30 секунд расхождения часов
+
+	recoveryCodesCount = 10
+)
+
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type MFAStatus struct {
+	TOTPEnabled       bool
+	RecoveryCodesLeft int
+}
+
+// BeginTOTP генерирует новый секрет; TOTP включится только после ConfirmTOTP.
+func (s *svc) BeginTOTP(ctx context.Context, adminID uuid.UUID) (TOTPEnrollment, error) {
+	a, err := s.repo.GetByID(ctx, adminID)
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+	if a.TOTPEnabled() {
+		return TOTPEnrollment{}, domain.ValidationError{}.Add("totp", "TOTP уже подключён")
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+	if err := s.repo.SetTOTP(ctx, adminID, &secret, nil); err != nil {
+		s.log.Error("BeginTOTP repo.SetTOTP failed", "admin_id", adminID.String(), "err", err)
+		return TOTPEnrollment{}, err
+	}
+
+	s.log.Info("BeginTOTP success", "admin_id", adminID.String())
+	return TOTPEnrollment{Secret: secret, URI: totp.URI(totpIssuer, a.Username, secret)}, nil
+}
+
+// ConfirmTOTP включает TOTP по первому верному коду и возвращает коды восстановления (показываются один раз).
+func (s *svc) ConfirmTOTP(ctx context.Context, adminID uuid.UUID, code string) ([]string, error) {
+	a, err := s.repo.GetByID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if a.TOTPEnabled() {
+		return nil, domain.ValidationError{}.Add("totp", "TOTP уже подключён")
+	}
+	if a.TOTPSecret == nil {
+		return nil, domain.ValidationError{}.Add("code", "Сначала начните подключение TOTP")
+	}
+
+	now := s.now().UTC()
+	step, ok := totp.Verify(*a.TOTPSecret, code, now, totpSkew)
+	if !ok {
+		s.log.Info("ConfirmTOTP failed", "admin_id", adminID.String(), "reason", "wrong_code")
+		return nil, domain.ValidationError{}.Add("code", "Неверный код")
+	}
+
+	if err := s.repo.SetTOTP(ctx, adminID, a.TOTPSecret, &now); err != nil {
+		s.log.Error("ConfirmTOTP repo.SetTOTP failed", "admin_id", adminID.String(), "err", err)
+		return nil, err
+	}
+	if _, err := s.repo.UseTOTPStep(ctx, adminID, step); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.issueRecoveryCodes(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("ConfirmTOTP success", "admin_id", adminID.String())
+	return codes, nil
+}
+
+// DisableTOTP требует действующий код (или код восстановления), чтобы украденная сессия не могла снять защиту.
+func (s *svc) DisableTOTP(ctx context.Context, adminID uuid.UUID, code, recoveryCode string) error {
+	if err := s.VerifySecondFactor(ctx, adminID, code, recoveryCode); err != nil {
+		return err
+	}
+	if err := s.repo.SetTOTP(ctx, adminID, nil, nil); err != nil {
+		s.log.Error("DisableTOTP repo.SetTOTP failed", "admin_id", adminID.String(), "err", err)
+		return err
+	}
+
+	s.log.Info("DisableTOTP success", "admin_id", adminID.String())
+	return nil
+}
+
+func (s *svc) ResetTOTP(ctx context.Context, username string) error {
+	username = NormalizeUsername(username)
+
+	a, err := s.repo.GetByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetTOTP(ctx, a.ID, nil, nil); err != nil {
+		s.log.Error("ResetTOTP repo.SetTOTP failed", "username", username, "err", err)
+		return err
+	}
+
+	s.log.Warn("ResetTOTP success", "username", username, "admin_id", a.ID.String())
+	return nil
+}
+
+// VerifySecondFactor проверяет TOTP-код или код восстановления; при неудаче — ErrUnauthorized.
+func (s *svc) VerifySecondFactor(ctx context.Context, adminID uuid.UUID, code, recoveryCode string) error {
+	a, err := s.repo.GetByID(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if !a.TOTPEnabled() {
+		return domain.ValidationError{}.Add("code", "TOTP не подключён")
+	}
+
+	now := s.now().UTC()
+	switch {
+	case strings.TrimSpace(code) != "":
+		step, ok := totp.Verify(*a.TOTPSecret, code, now, totpSkew)
+		if !ok {
+			s.log.Info("VerifySecondFactor failed", "admin_id", adminID.String(), "reason", "wrong_code")
+			return domain.ErrUnauthorized
+		}
+		fresh, err := s.repo.UseTOTPStep(ctx, adminID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			s.log.Info("VerifySecondFactor failed", "admin_id", adminID.String(), "reason", "code_reused")
+			return domain.ErrUnauthorized
+		}
+	case strings.TrimSpace(recoveryCode) != "":
+		used, err := s.repo.UseRecoveryCode(ctx, adminID, hashRecoveryCode(recoveryCode), now)
+		if err != nil {
+			return err
+		}
+		if !used {
+			s.log.Info("VerifySecondFactor failed", "admin_id", adminID.String(), "reason", "wrong_recovery_code")
+			return domain.ErrUnauthorized
+		}
+		s.log.Info("recovery code used", "admin_id", adminID.String())
+	default:
+		return domain.ValidationError{}.Add("code", "Укажите код из приложения или код восстановления")
+	}
+
+	return nil
+}
+
+func (s *svc) RegenerateRecoveryCodes(ctx context.Context, adminID uuid.UUID, code string) ([]string, error) {
+	if err := s.VerifySecondFactor(ctx, adminID, code, ""); err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(ctx, adminID)
+}
+
+func (s *svc) MFAStatus(ctx context.Context, adminID uuid.UUID) (MFAStatus, error) {
+	a, err := s.repo.GetByID(ctx, adminID)
+	if err != nil {
+		return MFAStatus{}, err
+	}
+	if !a.TOTPEnabled() {
+		return MFAStatus{}, nil
+	}
+
+	n, err := s.repo.CountRecoveryCodes(ctx, adminID)
+	if err != nil {
+		return MFAStatus{}, err
+	}
+	return MFAStatus{TOTPEnabled: true, RecoveryCodesLeft: n}, nil
+}
+
+func (s *svc) issueRecoveryCodes(ctx context.Context, adminID uuid.UUID) ([]string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		c, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+		hashes = append(hashes, hashRecoveryCode(c))
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(ctx, adminID, hashes); err != nil {
+		s.log.Error("issueRecoveryCodes repo.ReplaceRecoveryCodes failed", "admin_id", adminID.String(), "err", err)
+		return nil, err
+	}
+	return codes, nil
+}
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCode — 10 символов base32 (50 бит) в виде xxxxx-xxxxx.
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	raw := strings.ToLower(recoveryEncoding.EncodeToString(b))[:10]
+	return raw[:5] + "-" + raw[5:], nil
+}
+
+// hashRecoveryCode — коды случайные и длинные, поэтому достаточно SHA-256; регистр и дефисы игнорируются.
+func hashRecoveryCode(code string) string {
+	norm := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(norm))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,271 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/totp"
+)
+
+// fakeAdminRepo повторяет семантику Postgres-репозитория в памяти для одного администратора.
+type fakeAdminRepo struct {
+	admin    domain.Admin
+	lastStep *int64
+	codes    map[string]bool // хэш -> использован
+}
+
+func (f *fakeAdminRepo) Create(context.Context, string, string, domain.AdminRole) (domain.Admin, error) {
+	return domain.Admin{}, errors.New("not implemented")
+}
+
+func (f *fakeAdminRepo) GetByID(_ context.Context, id uuid.UUID) (domain.Admin, error) {
+	if id != f.admin.ID {
+		return domain.Admin{}, domain.ErrNotFound
+	}
+	return f.admin, nil
+}
+
+func (f *fakeAdminRepo) GetByUsername(_ context.Context, username string) (domain.Admin, error) {
+	if username != f.admin.Username {
+		return domain.Admin{}, domain.ErrNotFound
+	}
+	return f.admin, nil
+}
+
+func (f *fakeAdminRepo) UpdatePassword(context.Context, string, string) (domain.Admin, error) {
+	return domain.Admin{}, errors.New("not implemented")
+}
+
+func (f *fakeAdminRepo) UpdateRole(context.Context, string, domain.AdminRole) (domain.Admin, error) {
+	return domain.Admin{}, errors.New("not implemented")
+}
+
+func (f *fakeAdminRepo) Count(context.Context) (int, error) { return 1, nil }
+
+func (f *fakeAdminRepo) SetTOTP(_ context.Context, _ uuid.UUID, secret *string, enabledAt *time.Time) error {
+	f.admin.TOTPSecret = secret
+	f.admin.TOTPEnabledAt = enabledAt
+	if secret == nil {
+		f.lastStep = nil
+		f.codes = nil
+	}
+	return nil
+}
+
+func (f *fakeAdminRepo) UseTOTPStep(_ context.Context, _ uuid.UUID, step int64) (bool, error) {
+	if f.lastStep != nil && step <= *f.lastStep {
+		return false, nil
+	}
+	f.lastStep = &step
+	return true, nil
+}
+
+func (f *fakeAdminRepo) ReplaceRecoveryCodes(_ context.Context, _ uuid.UUID, hashes []string) error {
+	f.codes = make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		f.codes[h] = false
+	}
+	return nil
+}
+
+func (f *fakeAdminRepo) UseRecoveryCode(_ context.Context, _ uuid.UUID, hash string, _ time.Time) (bool, error) {
+	used, ok := f.codes[hash]
+	if !ok || used {
+		return false, nil
+	}
+	f.codes[hash] = true
+	return true, nil
+}
+
+func (f *fakeAdminRepo) CountRecoveryCodes(context.Context, uuid.UUID) (int, error) {
+	n := 0
+	for _, used := range f.codes {
+		if !used {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// clock — фиксированные часы, которые тест двигает вручную.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newMFAService(t *testing.T) (Service, *fakeAdminRepo, *clock) {
+	t.Helper()
+
+	repo := &fakeAdminRepo{admin: domain.Admin{ID: uuid.New(), Username: "anna", Role: domain.AdminRoleOwner}}
+	c := &clock{t: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	s, err := New(Deps{
+		Admins: repo,
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Now:    c.now,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, repo, c
+}
+
+func codeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	c, err := totp.Code(secret, totp.Step(at))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// enroll подключает TOTP и возвращает секрет и коды восстановления.
+func enroll(t *testing.T, s Service, repo *fakeAdminRepo, c *clock) (string, []string) {
+	t.Helper()
+
+	ctx := context.Background()
+	e, err := s.BeginTOTP(ctx, repo.admin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes, err := s.ConfirmTOTP(ctx, repo.admin.ID, codeAt(t, e.Secret, c.t))
+	if err != nil {
+		t.Fatalf("ConfirmTOTP: %v", err)
+	}
+	if len(codes) != recoveryCodesCount {
+		t.Fatalf("ConfirmTOTP returned %d recovery codes, want %d", len(codes), recoveryCodesCount)
+	}
+	return e.Secret, codes
+}
+
+func TestConfirmTOTPWrongCode(t *testing.T) {
+	s, repo, c := newMFAService(t)
+	ctx := context.Background()
+
+	e, err := s.BeginTOTP(ctx, repo.admin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong := codeAt(t, e.Secret, c.t.Add(-5*totp.Period))
+
+	_, err = s.ConfirmTOTP(ctx, repo.admin.ID, wrong)
+	var verr domain.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("ConfirmTOTP with stale code: err = %v, want ValidationError", err)
+	}
+	if repo.admin.TOTPEnabled() {
+		t.Fatal("TOTP enabled after a wrong code")
+	}
+}
+
+func TestVerifySecondFactorTOTP(t *testing.T) {
+	tests := []struct {
+		name    string
+		advance time.Duration // сдвиг часов после подключения
+		codeAt  time.Duration // момент кода относительно часов после сдвига
+		wantErr error
+	}{
+		{"code of the confirm step is a replay", 0, 0, domain.ErrUnauthorized},
+		{"next step", totp.Period, 0, nil},
+		{"one step of drift ahead", totp.Period, totp.Period, nil},
+		{"one step of drift behind", 3 * totp.Period, -totp.Period, nil},
+		{"two steps behind", 4 * totp.Period, -2 * totp.Period, domain.ErrUnauthorized},
+		{"two steps ahead", totp.Period, 2 * totp.Period, domain.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, c := newMFAService(t)
+			secret, _ := enroll(t, s, repo, c)
+
+			c.t = c.t.Add(tt.advance)
+			err := s.VerifySecondFactor(context.Background(), repo.admin.ID, codeAt(t, secret, c.t.Add(tt.codeAt)), "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifySecondFactor: err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifySecondFactorRejectsReplay(t *testing.T) {
+	s, repo, c := newMFAService(t)
+	secret, _ := enroll(t, s, repo, c)
+	ctx := context.Background()
+
+	c.t = c.t.Add(totp.Period)
+	code := codeAt(t, secret, c.t)
+	if err := s.VerifySecondFactor(ctx, repo.admin.ID, code, ""); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+
+	// Тот же код в пределах окна — повтор.
+	c.t = c.t.Add(10 * time.Second)
+	if err := s.VerifySecondFactor(ctx, repo.admin.ID, code, ""); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("replayed code: err = %v, want ErrUnauthorized", err)
+	}
+
+	// Код более раннего интервала после более позднего тоже не принимается.
+	c.t = c.t.Add(totp.Period)
+	if err := s.VerifySecondFactor(ctx, repo.admin.ID, codeAt(t, secret, c.t), ""); err != nil {
+		t.Fatalf("next step: %v", err)
+	}
+	if err := s.VerifySecondFactor(ctx, repo.admin.ID, codeAt(t, secret, c.t.Add(-totp.Period)), ""); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("older step after newer: err = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestRecoveryCodesSingleUse(t *testing.T) {
+	s, repo, c := newMFAService(t)
+	_, codes := enroll(t, s, repo, c)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{"first use", codes[0], nil},
+		{"second use", codes[0], domain.ErrUnauthorized},
+		{"upper case without dash", strings.ToUpper(strings.ReplaceAll(codes[1], "-", "")), nil},
+		{"same code in original form", codes[1], domain.ErrUnauthorized},
+		{"unknown code", "aaaaa-aaaaa", domain.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		if err := s.VerifySecondFactor(ctx, repo.admin.ID, "", tt.code); !errors.Is(err, tt.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	st, err := s.MFAStatus(ctx, repo.admin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.RecoveryCodesLeft != recoveryCodesCount-2 {
+		t.Fatalf("RecoveryCodesLeft = %d, want %d", st.RecoveryCodesLeft, recoveryCodesCount-2)
+	}
+}
+
+func TestRegenerateRecoveryCodesInvalidatesOld(t *testing.T) {
+	s, repo, c := newMFAService(t)
+	secret, old := enroll(t, s, repo, c)
+	ctx := context.Background()
+
+	c.t = c.t.Add(totp.Period)
+	fresh, err := s.RegenerateRecoveryCodes(ctx, repo.admin.ID, codeAt(t, secret, c.t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.VerifySecondFactor(ctx, repo.admin.ID, "", old[0]); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("old recovery code: err = %v, want ErrUnauthorized", err)
+	}
+	if err := s.VerifySecondFactor(ctx, repo.admin.ID, "", fresh[0]); err != nil {
+		t.Fatalf("new recovery code: %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+// DefaultUsername — логин, под которым создаётся первый администратор из ADMIN_PASSWORD
+// и который подставляется, если при входе логин не передан.
+const DefaultUsername = "admin"
+
+const (
+	minPasswordLen = 8
+	maxPasswordLen = 72 // ограничение bcrypt
+)
+
+var usernameRe = regexp.MustCompile(`^[a-z0-9._-]{3,32}$`)
+
+const roleValidationMsg = "Роль: owner, manager или viewer"
+
+type Service interface {
+	// Authenticate возвращает ErrUnauthorized и для неизвестного логина, и для неверного пароля.
+	Authenticate(ctx context.Context, username, password string) (domain.Admin, error)
+
+	CreateAdmin(ctx context.Context, username, password string, role domain.AdminRole) (domain.Admin, error)
+	// SetPassword одной транзакцией меняет пароль, завершает Postgres-сессии администратора и отзывает его API-ключи;
+	// сессии в памяти сервер перестаёт принимать на первом же запросе (пароль не совпадает с тем, что был при входе).
+	SetPassword(ctx context.Context, username, password string) (domain.Admin, error)
+	// SetRole меняет роль; в Postgres-сессиях она действует сразу, в памяти — со следующего входа.
+	SetRole(ctx context.Context, username string, role domain.AdminRole) (domain.Admin, error)
+
+	// EnsureBootstrap создаёт владельца (owner), только если таблица admins пуста.
+	EnsureBootstrap(ctx context.Context, username, password string) (bool, error)
+
+	BeginTOTP(ctx context.Context, adminID uuid.UUID) (TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, adminID uuid.UUID, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, adminID uuid.UUID, code, recoveryCode string) error
+	VerifySecondFactor(ctx context.Context, adminID uuid.UUID, code, recoveryCode string) error
+	RegenerateRecoveryCodes(ctx context.Context, adminID uuid.UUID, code string) ([]string, error)
+	MFAStatus(ctx context.Context, adminID uuid.UUID) (MFAStatus, error)
+
+	// ResetTOTP отключает TOTP без кода — только для CLI, когда потеряны и устройство, и коды восстановления.
+	ResetTOTP(ctx context.Context, username string) error
+}
+
+type Deps struct {
+	Admins repository.AdminRepository
+	Logger *slog.Logger
+
+	// Now — часы для проверки TOTP; nil — time.Now.
+	Now func() time.Time
+}
+
+type svc struct {
+	repo repository.AdminRepository
+	log  *slog.Logger
+	now  func() time.Time
+
+	// Сравнивается при неизвестном логине, чтобы время ответа не выдавало существование учётки.
+	dummyHash []byte
+}
+
+func New(d Deps) (Service, error) {
+	if d.Admins == nil {
+		return nil, fmt.Errorf("admin service: admins repo is nil")
+	}
+	log := d.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+	log = log.With("component", "admin_service")
+
+	dummy, err := bcrypt.GenerateFromPassword([]byte("photannie-dummy-password"), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("admin service: dummy hash: %w", err)
+	}
+
+	now := d.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	return &svc{repo: d.Admins, log: log, now: now, dummyHash: dummy}, nil
+}
+
+func (s *svc) Authenticate(ctx context.Context, username, password string) (domain.Admin, error) {
+	username = NormalizeUsername(username)
+
+	a, err := s.repo.GetByUsername(ctx, username)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		s.log.Error("Authenticate repo.GetByUsername failed", "username", username, "err", err)
+		return domain.Admin{}, err
+	}
+	if errors.Is(err, domain.ErrNotFound) {
+		_ = bcrypt.CompareHashAndPassword(s.dummyHash, []byte(password))
+		s.log.Info("Authenticate failed", "username", username, "reason", "unknown_username")
+		return domain.Admin{}, domain.ErrUnauthorized
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(a.PasswordHash), []byte(password)); err != nil {
+		s.log.Info("Authenticate failed", "username", username, "reason", "wrong_password")
+		return domain.Admin{}, domain.ErrUnauthorized
+	}
+
+	s.log.Info("Authenticate success", "username", username, "admin_id", a.ID.String())
+	return a, nil
+}
+
+func (s *svc) CreateAdmin(ctx context.Context, username, password string, role domain.AdminRole) (domain.Admin, error) {
+	username = NormalizeUsername(username)
+	s.log.Info("CreateAdmin start", "username", username, "role", string(role))
+
+	if !role.Valid() {
+		return domain.Admin{}, domain.ValidationError{}.Add("role", roleValidationMsg)
+	}
+	hash, err := s.hashCredentials(username, password)
+	if err != nil {
+		return domain.Admin{}, err
+	}
+
+	a, err := s.repo.Create(ctx, username, hash, role)
+	if err != nil {
+		s.log.Error("CreateAdmin repo.Create failed", "username", username, "err", err)
+		return domain.Admin{}, err
+	}
+
+	s.log.Info("CreateAdmin success", "username", username, "admin_id", a.ID.String())
+	return a, nil
+}
+
+func (s *svc) SetPassword(ctx context.Context, username, password string) (domain.Admin, error) {
+	username = NormalizeUsername(username)
+	s.log.Info("SetPassword start", "username", username)
+
+	hash, err := s.hashCredentials(username, password)
+	if err != nil {
+		return domain.Admin{}, err
+	}
+
+	a, err := s.repo.UpdatePassword(ctx, username, hash)
+	if err != nil {
+		s.log.Info("SetPassword failed", "username", username, "err", err)
+		return domain.Admin{}, err
+	}
+
+	s.log.Info("SetPassword success", "username", username, "admin_id", a.ID.String())
+	return a, nil
+}
+
+func (s *svc) SetRole(ctx context.Context, username string, role domain.AdminRole) (domain.Admin, error) {
+	username = NormalizeUsername(username)
+	s.log.Info("SetRole start", "username", username, "role", string(role))
+
+	if !role.Valid() {
+		return domain.Admin{}, domain.ValidationError{}.Add("role", roleValidationMsg)
+	}
+
+	a, err := s.repo.UpdateRole(ctx, username, role)
+	if err != nil {
+		s.log.Info("SetRole failed", "username", username, "err", err)
+		return domain.Admin{}, err
+	}
+
+	s.log.Info("SetRole success", "username", username, "admin_id", a.ID.String())
+	return a, nil
+}
+
+func (s *svc) EnsureBootstrap(ctx context.Context, username, password string) (bool, error) {
+	n, err := s.repo.Count(ctx)
+	if err != nil {
+		return false, err
+	}
+	if n > 0 {
+		return false, nil
+	}
+
+	if _, err := s.CreateAdmin(ctx, username, password, domain.AdminRoleOwner); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			// Параллельный старт другой реплики уже создал учётку.
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *svc) hashCredentials(username, password string) (string, error) {
+	verr := domain.ValidationError{}
+	if !usernameRe.MatchString(username) {
+		verr = verr.Add("username", "Логин: 3–32 символа, латиница в нижнем регистре, цифры, точка, дефис, подчёркивание")
+	}
+	if len(password) < minPasswordLen || len(password) > maxPasswordLen {
+		verr = verr.Add("password", fmt.Sprintf("Пароль должен быть от %d до %d байт", minPasswordLen, maxPasswordLen))
+	}
+	if !verr.IsEmpty() {
+		return "", verr
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("bcrypt: %w", err)
+	}
+	return string(hash), nil
+}
+
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
@@ -0,0 +1,179 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+// KeyPrefix — по нему ключ легко найти в конфигурации и логах сканеров секретов.
+const KeyPrefix = "pk_"
+
+const (
+	maxNameLen    = 100
+	displayPrefix = len(KeyPrefix) + 6 // сколько символов ключа хранится открыто
+)
+
+type Service interface {
+	// Create возвращает ключ целиком — второй раз его получить нельзя.
+	Create(ctx context.Context, creator domain.Admin, in CreateInput) (domain.APIKey, string, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+
+	// Authenticate возвращает администратора-владельца ключа с ограничением по scopes; ok=false — ключ недействителен.
+	Authenticate(ctx context.Context, key string) (domain.Admin, bool, error)
+}
+
+type CreateInput struct {
+	Name      string
+	Scopes    []domain.Permission
+	ExpiresAt *time.Time
+}
+
+type Deps struct {
+	Keys   repository.APIKeyRepository
+	Logger *slog.Logger
+}
+
+type svc struct {
+	repo repository.APIKeyRepository
+	log  *slog.Logger
+	now  func() time.Time
+}
+
+func New(d Deps) (Service, error) {
+	if d.Keys == nil {
+		return nil, fmt.Errorf("api key service: keys repo is nil")
+	}
+	log := d.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+	log = log.With("component", "api_key_service")
+
+	return &svc{repo: d.Keys, log: log, now: time.Now}, nil
+}
+
+func (s *svc) Create(ctx context.Context, creator domain.Admin, in CreateInput) (domain.APIKey, string, error) {
+	now := s.now().UTC()
+	name := strings.TrimSpace(in.Name)
+
+	s.log.Info("Create start", "name", name, "created_by", creator.Username)
+
+	verr := domain.ValidationError{}
+	if name == "" || utf8.RuneCountInString(name) > maxNameLen {
+		verr = verr.Add("name", fmt.Sprintf("Название: от 1 до %d символов", maxNameLen))
+	}
+	if len(in.Scopes) == 0 {
+		verr = verr.Add("scopes", "Укажите хотя бы одно право")
+	}
+	scopes := make([]domain.Permission, 0, len(in.Scopes))
+	seen := make(map[domain.Permission]bool, len(in.Scopes))
+	for _, p := range in.Scopes {
+		switch {
+		case !p.Valid():
+			verr = verr.Add("scopes", fmt.Sprintf("Неизвестное право %q", p))
+		case !creator.Can(p):
+			verr = verr.Add("scopes", fmt.Sprintf("Право %q шире вашей роли", p))
+		case !seen[p]:
+			seen[p] = true
+			scopes = append(scopes, p)
+		}
+	}
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(now) {
+		verr = verr.Add("expires_at", "Срок действия должен быть в будущем")
+	}
+	if !verr.IsEmpty() {
+		return domain.APIKey{}, "", verr
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	var expiresAt *time.Time
+	if in.ExpiresAt != nil {
+		t := in.ExpiresAt.UTC()
+		expiresAt = &t
+	}
+
+	k, err := s.repo.Create(ctx, repository.CreateAPIKeyParams{
+		Name:      name,
+		Prefix:    key[:displayPrefix],
+		KeyHash:   hashKey(key),
+		Scopes:    scopes,
+		CreatedBy: creator.ID,
+		NowUTC:    now,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		s.log.Error("Create repo.Create failed", "name", name, "err", err)
+		return domain.APIKey{}, "", err
+	}
+
+	s.log.Info("Create success", "api_key_id", k.ID.String(), "created_by", creator.Username)
+	return k, key, nil
+}
+
+func (s *svc) List(ctx context.Context) ([]domain.APIKey, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *svc) Revoke(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Revoke(ctx, id, s.now().UTC()); err != nil {
+		s.log.Info("Revoke failed", "api_key_id", id.String(), "err", err)
+		return err
+	}
+	s.log.Info("Revoke success", "api_key_id", id.String())
+	return nil
+}
+
+func (s *svc) Authenticate(ctx context.Context, key string) (domain.Admin, bool, error) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return domain.Admin{}, false, nil
+	}
+
+	k, err := s.repo.Use(ctx, hashKey(key), s.now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.Admin{}, false, nil
+	}
+	if err != nil {
+		return domain.Admin{}, false, err
+	}
+
+	id := k.ID
+	return domain.Admin{
+		ID:       k.CreatedBy,
+		Username: k.CreatedByUsername,
+		Role:     k.CreatedByRole,
+		APIKeyID: &id,
+		Scopes:   k.Scopes,
+	}, true, nil
+}
+
+func newKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return KeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
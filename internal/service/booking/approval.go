@@ -0,0 +1,68 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+const expiredPendingReason = "Заявка не подтверждена в срок"
+
+// requiresApproval — настройка услуги важнее общей.
+func (s *svc) requiresApproval(service *domain.StudioService) bool {
+	if service != nil && service.RequiresApproval != nil {
+		return *service.RequiresApproval
+	}
+	return s.rules.approvalRequired
+}
+
+func (s *svc) ListPendingBookings(ctx context.Context) ([]domain.Booking, error) {
+	items, err := s.repo.ListPending(ctx)
+	if err != nil {
+		s.log.Error("ListPendingBookings repo.ListPending failed", "err", err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *svc) ApproveBooking(ctx context.Context, id uuid.UUID) (domain.Booking, error) {
+	return s.adminTransition(ctx, "ApproveBooking", id, repository.UpdateBookingStatusParams{To: domain.BookingStatusConfirmed}, pendingNotExpired)
+}
+
+// pendingNotExpired — истёкшую заявку уже не подтвердить, даже если фоновая задача ещё не успела её отменить.
+func pendingNotExpired(b domain.Booking, nowUTC time.Time) error {
+	if b.Status == domain.BookingStatusPending && b.PendingExpiresAt != nil && !nowUTC.Before(*b.PendingExpiresAt) {
+		return domain.ValidationError{}.Add("booking_id", "Срок заявки истёк")
+	}
+	return nil
+}
+
+// RejectBooking отклоняет только заявку; подтверждённую запись отменяют через CancelBooking.
+func (s *svc) RejectBooking(ctx context.Context, id uuid.UUID, reason *string) (domain.Booking, error) {
+	return s.adminTransition(ctx, "RejectBooking", id, repository.UpdateBookingStatusParams{
+		To:     domain.BookingStatusCancelled,
+		Reason: reason,
+		Source: domain.BookingActorAdmin,
+	}, func(b domain.Booking, _ time.Time) error {
+		if b.Status != domain.BookingStatusPending {
+			return domain.ValidationError{}.Add("booking_id", "Заявка уже рассмотрена")
+		}
+		return nil
+	})
+}
+
+func (s *svc) ExpirePendingBookings(ctx context.Context) (int64, error) {
+	n, err := s.repo.ExpirePending(ctx, time.Now().UTC(), expiredPendingReason)
+	if err != nil {
+		s.log.Error("ExpirePendingBookings repo.ExpirePending failed", "err", err)
+		return 0, err
+	}
+	if n > 0 {
+		s.log.Info("ExpirePendingBookings done", "expired", n)
+	}
+	return n, nil
+}
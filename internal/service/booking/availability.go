@@ -0,0 +1,128 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type AvailabilityQuery struct {
+	From time.Time
+	To   time.Time // включительно
+
+	DurationMinutes int
+	ServiceID       *uuid.UUID
+}
+
+type DayStatus string
+
+const (
+	DayStatusAvailable DayStatus = "available"
+	DayStatusFull      DayStatus = "full"
+	DayStatusClosed    DayStatus = "closed"
+)
+
+type DayAvailability struct {
+	Date      time.Time
+	Status    DayStatus
+	FreeSlots int
+}
+
+type Availability struct {
+	// Фактический период после ограничения окном записи.
+	From time.Time
+	To   time.Time
+
+	DurationMinutes int
+	Days            []DayAvailability
+}
+
+// GetAvailability считает свободные слоты по дням периода одним запросом записей и одним запросом закрытий.
+// Период обрезается до [сегодня, сегодня + BookingWindowDays].
+func (s *svc) GetAvailability(ctx context.Context, q AvailabilityQuery) (Availability, error) {
+	fromLocal := s.dateOnlyLocal(q.From)
+	toLocal := s.dateOnlyLocal(q.To)
+	s.log.Debug("GetAvailability start", "from", fromLocal.Format("2006-01-02"), "to", toLocal.Format("2006-01-02"))
+
+	if toLocal.Before(fromLocal) {
+		return Availability{}, domain.ValidationError{}.Add("to", "Дата окончания не может быть раньше даты начала")
+	}
+
+	nowLocal := time.Now().In(s.rules.loc)
+	today := s.dateOnlyLocal(nowLocal)
+	limit := today.AddDate(0, 0, s.rules.bookingWindowDays)
+	if fromLocal.Before(today) {
+		fromLocal = today
+	}
+	if toLocal.After(limit) {
+		toLocal = limit
+	}
+	if toLocal.Before(fromLocal) {
+		return Availability{}, domain.ValidationError{}.Add("from", "Период вне окна записи")
+	}
+
+	durationMinutes, _, verr, err := s.resolveDuration(ctx, q.DurationMinutes, q.ServiceID, s.rules.slotMinutes, domain.ValidationError{})
+	if err != nil {
+		s.log.Error("GetAvailability resolveDuration failed", "err", err)
+		return Availability{}, err
+	}
+	if !verr.IsEmpty() {
+		s.log.Info("GetAvailability validation failed", "err", verr)
+		return Availability{}, verr
+	}
+
+	rangeEndLocal := toLocal.AddDate(0, 0, 1)
+	bookings, err := s.repo.ListByRange(ctx, repository.ListBookingsByRangeParams{
+		RangeStart: fromLocal.Add(-s.rules.bufferBefore).UTC(),
+		RangeEnd:   rangeEndLocal.Add(s.rules.bufferAfter).UTC(),
+	})
+	if err != nil {
+		s.log.Error("GetAvailability repo.ListByRange failed", "err", err)
+		return Availability{}, err
+	}
+
+	closures, err := s.closures.ListByRange(ctx, repository.ListClosuresByRangeParams{
+		FromDate: fromLocal,
+		ToDate:   toLocal,
+	})
+	if err != nil {
+		s.log.Error("GetAvailability closures.ListByRange failed", "err", err)
+		return Availability{}, err
+	}
+
+	days := make([]DayAvailability, 0, int(toLocal.Sub(fromLocal).Hours()/24)+1)
+	for d := fromLocal; !d.After(toLocal); d = d.AddDate(0, 0, 1) {
+		day := DayAvailability{Date: d, Status: DayStatusClosed}
+		if len(s.workRanges(d)) > 0 && !closedOn(d, closures) {
+			day.FreeSlots = len(s.freeStarts(d, nowLocal, durationMinutes, bookings))
+			day.Status = DayStatusFull
+			if day.FreeSlots > 0 {
+				day.Status = DayStatusAvailable
+			}
+		}
+		days = append(days, day)
+	}
+
+	s.log.Debug("GetAvailability done", "days", len(days), "bookings", len(bookings))
+	return Availability{
+		From:            fromLocal,
+		To:              toLocal,
+		DurationMinutes: durationMinutes,
+		Days:            days,
+	}, nil
+}
+
+// closedOn — попадает ли дата (полночь TZ студии) в одно из закрытий; даты закрытий из БД сравниваются по Y/M/D.
+func closedOn(dateLocal time.Time, closures []domain.Closure) bool {
+	key := dateLocal.Format("2006-01-02")
+	for _, c := range closures {
+		if key >= c.StartDate.Format("2006-01-02") && key <= c.EndDate.Format("2006-01-02") {
+			return true
+		}
+	}
+	return false
+}
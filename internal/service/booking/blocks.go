@@ -0,0 +1,130 @@
+package booking
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type CreateBlockInput struct {
+	Date            time.Time
+	StartTimeHHMM   string
+	DurationMinutes int
+	Reason          *string
+}
+
+const (
+	maxBlockReasonLen = 300
+	minutesPerDay     = 24 * 60
+)
+
+// CreateBlock занимает интервал без клиента. В отличие от публичной записи,
+// блокировка не ограничена рабочими часами и окном записи, но должна лежать на сетке слотов.
+func (s *svc) CreateBlock(ctx context.Context, in CreateBlockInput) (domain.Booking, error) {
+	reqDateLocal := s.dateOnlyLocal(in.Date)
+	s.log.Info("CreateBlock start",
+		"date", reqDateLocal.Format("2006-01-02"),
+		"start_time", in.StartTimeHHMM,
+		"duration_min", in.DurationMinutes,
+	)
+
+	verr := domain.ValidationError{}
+
+	todayLocal := s.dateOnlyLocal(time.Now().In(s.rules.loc))
+	if reqDateLocal.Before(todayLocal) {
+		verr = verr.Add("date", "Дата не может быть в прошлом")
+	}
+
+	startMin, err := parseHHMMToMinutes(in.StartTimeHHMM)
+	if err != nil {
+		verr = verr.Add("start_time", "Время должно быть в формате HH:MM")
+	} else if startMin%s.rules.slotMinutes != 0 {
+		verr = verr.Add("start_time", s.startGridMessage())
+	}
+
+	if in.DurationMinutes < s.rules.slotMinutes || in.DurationMinutes%s.rules.slotMinutes != 0 {
+		verr = verr.Add("duration_minutes", s.durationGridMessage())
+	} else if err == nil && startMin+in.DurationMinutes > minutesPerDay {
+		verr = verr.Add("duration_minutes", "Блокировка должна заканчиваться в тот же день")
+	}
+
+	var reason *string
+	if in.Reason != nil {
+		if r := strings.TrimSpace(*in.Reason); r != "" {
+			if len([]rune(r)) > maxBlockReasonLen {
+				verr = verr.Add("reason", "Причина слишком длинная")
+			}
+			reason = &r
+		}
+	}
+
+	if !verr.IsEmpty() {
+		s.log.Info("CreateBlock validation failed", "date", reqDateLocal.Format("2006-01-02"), "err", verr)
+		return domain.Booking{}, verr
+	}
+
+	dayStartLocal, _ := s.dayBoundsLocal(in.Date)
+	startLocal := dayStartLocal.Add(time.Duration(startMin) * time.Minute)
+	endLocal := startLocal.Add(time.Duration(in.DurationMinutes) * time.Minute)
+
+	created, err := s.repo.Create(ctx, repository.CreateBookingParams{
+		Kind:    domain.BookingKindBlock,
+		StartAt: startLocal.UTC(),
+		EndAt:   endLocal.UTC(),
+		Comment: reason,
+	})
+	if err != nil {
+		s.log.Info("CreateBlock failed",
+			"date", reqDateLocal.Format("2006-01-02"),
+			"start_time", in.StartTimeHHMM,
+			"duration_min", in.DurationMinutes,
+			"err", err,
+		)
+		return domain.Booking{}, err
+	}
+
+	s.log.Info("CreateBlock success", "block_id", created.ID.String(), "date", reqDateLocal.Format("2006-01-02"))
+	return created, nil
+}
+
+func (s *svc) ListBlocksByDate(ctx context.Context, date time.Time) ([]domain.Booking, error) {
+	dateLocal := s.dateOnlyLocal(date)
+	s.log.Debug("ListBlocksByDate start", "date", dateLocal.Format("2006-01-02"))
+
+	dayStartLocal, dayEndLocal := s.dayBoundsLocal(date)
+	items, err := s.repo.ListByRange(ctx, repository.ListBookingsByRangeParams{
+		RangeStart: dayStartLocal.UTC(),
+		RangeEnd:   dayEndLocal.UTC(),
+	})
+	if err != nil {
+		s.log.Error("ListBlocksByDate repo.ListByRange failed", "date", dateLocal.Format("2006-01-02"), "err", err)
+		return nil, err
+	}
+
+	out := make([]domain.Booking, 0, len(items))
+	for _, b := range items {
+		if b.IsBlock() {
+			out = append(out, b)
+		}
+	}
+
+	s.log.Debug("ListBlocksByDate done", "date", dateLocal.Format("2006-01-02"), "items", len(out))
+	return out, nil
+}
+
+func (s *svc) DeleteBlock(ctx context.Context, id uuid.UUID) error {
+	s.log.Info("DeleteBlock start", "block_id", id.String())
+
+	if err := s.repo.DeleteBlock(ctx, id); err != nil {
+		s.log.Info("DeleteBlock failed", "block_id", id.String(), "err", err)
+		return err
+	}
+
+	s.log.Info("DeleteBlock success", "block_id", id.String())
+	return nil
+}
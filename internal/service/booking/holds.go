@@ -0,0 +1,136 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+const holdExpiredMessage = "Удержание слота истекло, выберите время заново"
+
+type CreateHoldInput struct {
+	Date            time.Time
+	StartTimeHHMM   string
+	DurationMinutes int // 0 — взять из услуги
+	ServiceID       *uuid.UUID
+
+	// ReplaceToken — токен прежнего удержания: клиент выбрал другое время, и старое снимается вместе с созданием нового.
+	ReplaceToken string
+
+	// Requester — IP клиента, по которому считаются лимиты удержаний.
+	Requester string
+}
+
+// CreateHold держит интервал за клиентом HoldTTL, пока он заполняет форму записи.
+// Проверки те же, что у CreateBooking; занятое время — ErrTimeTaken, превышение лимитов — TooManyRequestsError.
+func (s *svc) CreateHold(ctx context.Context, in CreateHoldInput) (domain.SlotHold, string, error) {
+	reqDateLocal := s.dateOnlyLocal(in.Date)
+	s.log.Info("CreateHold start",
+		"date", reqDateLocal.Format("2006-01-02"),
+		"start_time", in.StartTimeHHMM,
+		"duration_min", in.DurationMinutes,
+	)
+
+	if err := s.validatePublicDate(ctx, in.Date); err != nil {
+		s.log.Info("CreateHold validation failed (date)", "date", reqDateLocal.Format("2006-01-02"), "err", err)
+		return domain.SlotHold{}, "", err
+	}
+
+	startMin, workRange, verr := s.parseStart(reqDateLocal, in.StartTimeHHMM, domain.ValidationError{})
+
+	durationMinutes, _, verr, err := s.resolveDuration(ctx, in.DurationMinutes, in.ServiceID, 0, verr)
+	if err != nil {
+		s.log.Error("CreateHold resolveDuration failed", "err", err)
+		return domain.SlotHold{}, "", err
+	}
+	if !verr.IsEmpty() {
+		s.log.Info("CreateHold validation failed", "date", reqDateLocal.Format("2006-01-02"), "err", verr)
+		return domain.SlotHold{}, "", verr
+	}
+
+	startLocal, endLocal := s.sessionBounds(reqDateLocal, startMin, durationMinutes)
+	if err := s.checkSessionTime(reqDateLocal, startLocal, endLocal, workRange, true); err != nil {
+		s.log.Info("CreateHold validation failed (time)", "date", reqDateLocal.Format("2006-01-02"), "err", err)
+		return domain.SlotHold{}, "", err
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		s.log.Error("CreateHold newSecretToken failed", "err", err)
+		return domain.SlotHold{}, "", err
+	}
+
+	startUTC := startLocal.UTC()
+	endUTC := endLocal.UTC()
+	now := time.Now().UTC()
+
+	params := repository.CreateSlotHoldParams{
+		StartAt:         startUTC,
+		EndAt:           endUTC,
+		OccupiedStartAt: startUTC.Add(-s.rules.bufferBefore),
+		OccupiedEndAt:   endUTC.Add(s.rules.bufferAfter),
+		TokenHash:       hashSecretToken(token),
+		ExpiresAt:       now.Add(s.rules.holdTTL),
+		StudioDate:      reqDateLocal,
+
+		Requester:       in.Requester,
+		RateWindowStart: now.Add(-s.rules.holdRateWindow),
+		MaxRequests:     s.rules.holdRateLimit,
+		MaxActive:       s.rules.maxActiveHolds,
+	}
+	if in.ReplaceToken != "" {
+		params.ReplaceTokenHash = hashSecretToken(in.ReplaceToken)
+	}
+
+	hold, err := s.repo.CreateHold(ctx, params)
+	if err != nil {
+		err = closedDateError(err)
+		s.log.Info("CreateHold failed",
+			"date", reqDateLocal.Format("2006-01-02"),
+			"start_time", in.StartTimeHHMM,
+			"duration_min", durationMinutes,
+			"err", err,
+		)
+		return domain.SlotHold{}, "", err
+	}
+
+	s.log.Info("CreateHold success", "hold_id", hold.ID.String(), "expires_at", hold.ExpiresAt)
+	return hold, token, nil
+}
+
+// checkHold проверяет, что удержание действует и выписано ровно на интервал записи.
+func (s *svc) checkHold(ctx context.Context, token string, startUTC, endUTC time.Time) error {
+	hold, err := s.repo.GetHold(ctx, hashSecretToken(token), time.Now().UTC())
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ValidationError{}.Add("hold_token", holdExpiredMessage)
+	}
+	if err != nil {
+		return err
+	}
+	if !hold.StartAt.Equal(startUTC) || !hold.EndAt.Equal(endUTC) {
+		return domain.ValidationError{}.Add("hold_token", "Слот удержан на другое время")
+	}
+	return nil
+}
+
+func (s *svc) ReleaseExpiredHolds(ctx context.Context) (int64, error) {
+	now := time.Now().UTC()
+	n, err := s.repo.DeleteExpiredHolds(ctx, now)
+	if err != nil {
+		s.log.Error("ReleaseExpiredHolds repo.DeleteExpiredHolds failed", "err", err)
+		return 0, err
+	}
+	if _, err := s.repo.DeleteHoldRequestsBefore(ctx, now.Add(-s.rules.holdRateWindow)); err != nil {
+		s.log.Error("ReleaseExpiredHolds repo.DeleteHoldRequestsBefore failed", "err", err)
+		return 0, err
+	}
+	if n > 0 {
+		s.log.Info("ReleaseExpiredHolds done", "released", n)
+	}
+	return n, nil
+}
@@ -0,0 +1,96 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+func (s *svc) ConfirmBooking(ctx context.Context, id uuid.UUID) (domain.Booking, error) {
+	return s.adminTransition(ctx, "ConfirmBooking", id, repository.UpdateBookingStatusParams{To: domain.BookingStatusConfirmed}, pendingNotExpired)
+}
+
+func (s *svc) CompleteBooking(ctx context.Context, id uuid.UUID) (domain.Booking, error) {
+	return s.adminTransition(ctx, "CompleteBooking", id, repository.UpdateBookingStatusParams{To: domain.BookingStatusCompleted}, sessionStarted)
+}
+
+func (s *svc) MarkNoShow(ctx context.Context, id uuid.UUID) (domain.Booking, error) {
+	return s.adminTransition(ctx, "MarkNoShow", id, repository.UpdateBookingStatusParams{To: domain.BookingStatusNoShow}, sessionStarted)
+}
+
+// sessionStarted — отметить «состоялась» или «не пришёл» можно только начавшийся сеанс.
+func sessionStarted(b domain.Booking, nowUTC time.Time) error {
+	if nowUTC.Before(b.StartAt) {
+		return domain.ValidationError{}.Add("booking_id", "Сеанс ещё не начался")
+	}
+	return nil
+}
+
+// adminTransition — смена состояния администратором (логин берётся из контекста).
+// check — дополнительная проверка записи перед переходом; повтор уже выполненного перехода её не проходит.
+func (s *svc) adminTransition(ctx context.Context, op string, id uuid.UUID, p repository.UpdateBookingStatusParams, check func(domain.Booking, time.Time) error) (domain.Booking, error) {
+	a, ok := domain.AdminFromContext(ctx)
+	if ok && a.Username != "" && p.To == domain.BookingStatusCancelled {
+		p.CancelledBy = &a.Username
+	}
+	s.log.Info(op+" start", "booking_id", id.String(), "by", a.Username)
+
+	b, err := s.repo.GetByID(ctx, id)
+	if err == nil && !b.IsClient() {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		s.log.Info(op+" failed", "booking_id", id.String(), "err", err)
+		return domain.Booking{}, err
+	}
+
+	p.NowUTC = time.Now().UTC()
+	if check != nil && b.Status != p.To {
+		if err := check(b, p.NowUTC); err != nil {
+			s.log.Info(op+" validation failed", "booking_id", id.String(), "err", err)
+			return domain.Booking{}, err
+		}
+	}
+
+	out, err := s.transition(ctx, b, p)
+	if err != nil {
+		s.log.Info(op+" failed", "booking_id", id.String(), "status", string(b.Status), "err", err)
+		return domain.Booking{}, err
+	}
+
+	s.log.Info(op+" success", "booking_id", id.String(), "status", string(out.Status))
+	return out, nil
+}
+
+// transition переводит запись b в состояние p.To. Повтор уже выполненного перехода возвращает
+// запись как есть, недопустимый переход — BookingTransitionError.
+func (s *svc) transition(ctx context.Context, b domain.Booking, p repository.UpdateBookingStatusParams) (domain.Booking, error) {
+	if b.Status == p.To {
+		return b, nil
+	}
+	if !b.Status.CanTransitionTo(p.To) {
+		return domain.Booking{}, domain.BookingTransitionError{From: b.Status, To: p.To}
+	}
+
+	p.ID = b.ID
+	p.From = []domain.BookingStatus{b.Status}
+	out, err := s.repo.UpdateStatus(ctx, p)
+	if !errors.Is(err, domain.ErrNotFound) {
+		return out, err
+	}
+
+	// Состояние успело смениться параллельным запросом.
+	cur, err := s.repo.GetByID(ctx, b.ID)
+	if err != nil {
+		return domain.Booking{}, err
+	}
+	if cur.Status == p.To {
+		return cur, nil
+	}
+	return domain.Booking{}, domain.BookingTransitionError{From: cur.Status, To: p.To}
+}
@@ -0,0 +1,111 @@
+package booking
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+const maxCancelReasonLen = 300
+
+func (s *svc) GetClientBooking(ctx context.Context, id uuid.UUID, token string) (domain.Booking, error) {
+	s.log.Debug("GetClientBooking start", "booking_id", id.String())
+
+	b, err := s.clientBooking(ctx, id, token)
+	if err != nil {
+		s.log.Info("GetClientBooking failed", "booking_id", id.String(), "err", err)
+		return domain.Booking{}, err
+	}
+
+	s.log.Debug("GetClientBooking success", "booking_id", id.String(), "status", string(b.Status))
+	return b, nil
+}
+
+// CancelClientBooking — отмена самим клиентом; как и админская отмена, повтор возвращает текущее состояние.
+// Начавшуюся или прошедшую запись клиент отменить не может.
+func (s *svc) CancelClientBooking(ctx context.Context, id uuid.UUID, token string, reason *string) (domain.Booking, error) {
+	s.log.Info("CancelClientBooking start", "booking_id", id.String())
+
+	b, err := s.clientBooking(ctx, id, token)
+	if err != nil {
+		s.log.Info("CancelClientBooking failed", "booking_id", id.String(), "err", err)
+		return domain.Booking{}, err
+	}
+	if b.IsCancelled() {
+		s.log.Info("CancelClientBooking already cancelled", "booking_id", id.String())
+		return b, nil
+	}
+
+	verr := domain.ValidationError{}
+	nowUTC := time.Now().UTC()
+	if !nowUTC.Before(b.StartAt) {
+		verr = verr.Add("booking_id", "Запись уже началась или прошла, отменить её нельзя")
+	}
+	if reason != nil && len([]rune(*reason)) > maxCancelReasonLen {
+		verr = verr.Add("reason", "Причина не длиннее 300 символов")
+	}
+	if !verr.IsEmpty() {
+		s.log.Info("CancelClientBooking validation failed", "booking_id", id.String(), "err", verr)
+		return domain.Booking{}, verr
+	}
+
+	b, err = s.transition(ctx, b, repository.UpdateBookingStatusParams{
+		To:     domain.BookingStatusCancelled,
+		NowUTC: nowUTC,
+		Reason: reason,
+		Source: domain.BookingActorClient,
+	})
+	if err != nil {
+		s.log.Info("CancelClientBooking failed", "booking_id", id.String(), "err", err)
+		return domain.Booking{}, err
+	}
+
+	s.log.Info("CancelClientBooking success", "booking_id", id.String(), "status", string(b.Status))
+	return b, nil
+}
+
+func (s *svc) ReissueManageToken(ctx context.Context, id uuid.UUID) (string, error) {
+	s.log.Info("ReissueManageToken start", "booking_id", id.String())
+
+	token, err := newSecretToken()
+	if err != nil {
+		s.log.Error("ReissueManageToken token generation failed", "err", err)
+		return "", err
+	}
+	if err := s.repo.SetManageTokenHash(ctx, id, hashSecretToken(token)); err != nil {
+		s.log.Info("ReissueManageToken failed", "booking_id", id.String(), "err", err)
+		return "", err
+	}
+
+	s.log.Info("ReissueManageToken success", "booking_id", id.String())
+	return token, nil
+}
+
+func (s *svc) clientBooking(ctx context.Context, id uuid.UUID, token string) (domain.Booking, error) {
+	if token == "" {
+		return domain.Booking{}, domain.ErrNotFound
+	}
+	return s.repo.GetByManageToken(ctx, id, hashSecretToken(token))
+}
+
+// newSecretToken — токен ссылки управления записью или удержания слота; в БД хранится только hashSecretToken.
+func newSecretToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,129 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+// RescheduleInput — новое начало сеанса; длительность, услуга и цена остаются прежними.
+type RescheduleInput struct {
+	Date          time.Time
+	StartTimeHHMM string
+}
+
+// RescheduleBooking — перенос администратором (логин берётся из контекста). Новое время проверяется
+// по рабочему графику, но без запаса до начала и закрытия записи на сегодня.
+func (s *svc) RescheduleBooking(ctx context.Context, id uuid.UUID, in RescheduleInput) (domain.Booking, error) {
+	var by *string
+	a, ok := domain.AdminFromContext(ctx)
+	if ok && a.Username != "" {
+		by = &a.Username
+	}
+	s.log.Info("RescheduleBooking start", "booking_id", id.String(), "by", a.Username)
+
+	b, err := s.repo.GetByID(ctx, id)
+	if err == nil && !b.IsClient() {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		s.log.Info("RescheduleBooking failed", "booking_id", id.String(), "err", err)
+		return domain.Booking{}, err
+	}
+
+	out, err := s.reschedule(ctx, b, in, domain.BookingActorAdmin, by)
+	if err != nil {
+		s.log.Info("RescheduleBooking failed", "booking_id", id.String(), "err", err)
+		return domain.Booking{}, err
+	}
+
+	s.log.Info("RescheduleBooking success", "booking_id", id.String(), "start_at", out.StartAt)
+	return out, nil
+}
+
+// RescheduleClientBooking — перенос самим клиентом по ссылке управления; действуют все правила публичной записи,
+// а начавшуюся запись перенести нельзя.
+func (s *svc) RescheduleClientBooking(ctx context.Context, id uuid.UUID, token string, in RescheduleInput) (domain.Booking, error) {
+	s.log.Info("RescheduleClientBooking start", "booking_id", id.String())
+
+	b, err := s.clientBooking(ctx, id, token)
+	if err != nil {
+		s.log.Info("RescheduleClientBooking failed", "booking_id", id.String(), "err", err)
+		return domain.Booking{}, err
+	}
+	if !b.IsCancelled() && !time.Now().Before(b.StartAt) {
+		err := domain.ValidationError{}.Add("booking_id", "Запись уже началась или прошла, перенести её нельзя")
+		s.log.Info("RescheduleClientBooking validation failed", "booking_id", id.String(), "err", err)
+		return domain.Booking{}, err
+	}
+
+	out, err := s.reschedule(ctx, b, in, domain.BookingActorClient, nil)
+	if err != nil {
+		s.log.Info("RescheduleClientBooking failed", "booking_id", id.String(), "err", err)
+		return domain.Booking{}, err
+	}
+
+	s.log.Info("RescheduleClientBooking success", "booking_id", id.String(), "start_at", out.StartAt)
+	return out, nil
+}
+
+func (s *svc) ListReschedules(ctx context.Context, id uuid.UUID) ([]domain.BookingReschedule, error) {
+	items, err := s.repo.ListReschedules(ctx, id)
+	if err != nil {
+		s.log.Error("ListReschedules repo.ListReschedules failed", "booking_id", id.String(), "err", err)
+		return nil, err
+	}
+	return items, nil
+}
+
+// reschedule проверяет новое время и переносит запись; пересечение с другими записями
+// ловит ограничение bookings_no_overlap_active — это ErrTimeTaken.
+func (s *svc) reschedule(ctx context.Context, b domain.Booking, in RescheduleInput, actor domain.BookingActor, by *string) (domain.Booking, error) {
+	if b.IsCancelled() {
+		return domain.Booking{}, domain.ValidationError{}.Add("booking_id", "Отменённую запись перенести нельзя")
+	}
+	if b.Status.IsFinal() {
+		return domain.Booking{}, domain.ValidationError{}.Add("booking_id", "Завершённую запись перенести нельзя")
+	}
+
+	public := actor == domain.BookingActorClient
+	if err := s.validatePublicDate(ctx, in.Date); err != nil {
+		return domain.Booking{}, err
+	}
+
+	dateLocal := s.dateOnlyLocal(in.Date)
+	startMin, workRange, verr := s.parseStart(dateLocal, in.StartTimeHHMM, domain.ValidationError{})
+	if !verr.IsEmpty() {
+		return domain.Booking{}, verr
+	}
+
+	durationMinutes := int(b.EndAt.Sub(b.StartAt) / time.Minute)
+	startLocal, endLocal := s.sessionBounds(dateLocal, startMin, durationMinutes)
+	if err := s.checkSessionTime(dateLocal, startLocal, endLocal, workRange, public); err != nil {
+		return domain.Booking{}, err
+	}
+
+	startUTC, endUTC := startLocal.UTC(), endLocal.UTC()
+	if startUTC.Equal(b.StartAt) {
+		return domain.Booking{}, domain.ValidationError{}.Add("start_time", "Запись уже стоит на это время")
+	}
+
+	updated, err := s.repo.Reschedule(ctx, repository.RescheduleBookingParams{
+		ID:              b.ID,
+		StartAt:         startUTC,
+		EndAt:           endUTC,
+		OccupiedStartAt: startUTC.Add(-s.rules.bufferBefore),
+		OccupiedEndAt:   endUTC.Add(s.rules.bufferAfter),
+		Source:          actor,
+		RescheduledBy:   by,
+		StudioDate:      dateLocal,
+	})
+	if err != nil {
+		return domain.Booking{}, closedDateError(err)
+	}
+	return updated, nil
+}
@@ -2,9 +2,12 @@ package booking
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"regexp"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,75 +17,180 @@ import (
 )
 
 type Service interface {
-	GetFreeSlots(ctx context.Context, date time.Time) ([]string, error)
-	CreateBooking(ctx context.Context, in CreateBookingInput) (domain.Booking, error)
+	GetFreeSlots(ctx context.Context, q FreeSlotsQuery) (FreeSlots, error)
+	GetAvailability(ctx context.Context, q AvailabilityQuery) (Availability, error)
+	// CreateBooking возвращает и токен ссылки управления записью — хранится только его хэш.
+	CreateBooking(ctx context.Context, in CreateBookingInput) (domain.Booking, string, error)
+	// ReissueManageToken выдаёт записи новый токен ссылки управления, прежний перестаёт действовать.
+	// Нужен повтору CreateBooking по Idempotency-Key: сохранённый ответ токена не содержит.
+	ReissueManageToken(ctx context.Context, id uuid.UUID) (string, error)
+
+	// Операции клиента по ссылке управления записью; неверный токен неотличим от несуществующей записи.
+	GetClientBooking(ctx context.Context, id uuid.UUID, token string) (domain.Booking, error)
+	CancelClientBooking(ctx context.Context, id uuid.UUID, token string, reason *string) (domain.Booking, error)
+	RescheduleClientBooking(ctx context.Context, id uuid.UUID, token string, in RescheduleInput) (domain.Booking, error)
 
 	ListBookingsByDate(ctx context.Context, date time.Time) ([]domain.Booking, error)
 	GetBooking(ctx context.Context, id uuid.UUID) (domain.Booking, error)
 	CancelBooking(ctx context.Context, id uuid.UUID, reason *string) (domain.Booking, error)
+	ConfirmBooking(ctx context.Context, id uuid.UUID) (domain.Booking, error)
+	CompleteBooking(ctx context.Context, id uuid.UUID) (domain.Booking, error)
+	MarkNoShow(ctx context.Context, id uuid.UUID) (domain.Booking, error)
+
+	// Заявки в режиме подтверждения: одобрение переводит в confirmed, отклонение — отмена заявки.
+	ListPendingBookings(ctx context.Context) ([]domain.Booking, error)
+	ApproveBooking(ctx context.Context, id uuid.UUID) (domain.Booking, error)
+	RejectBooking(ctx context.Context, id uuid.UUID, reason *string) (domain.Booking, error)
+	// ExpirePendingBookings отменяет заявки с истёкшим сроком и освобождает их время; для фоновой задачи.
+	ExpirePendingBookings(ctx context.Context) (int64, error)
+
+	// CreateHold возвращает и токен удержания — CreateBookingInput.HoldToken; хранится только его хэш.
+	CreateHold(ctx context.Context, in CreateHoldInput) (domain.SlotHold, string, error)
+	// ReleaseExpiredHolds удаляет истёкшие удержания слотов; для фоновой задачи.
+	ReleaseExpiredHolds(ctx context.Context) (int64, error)
+
+	RescheduleBooking(ctx context.Context, id uuid.UUID, in RescheduleInput) (domain.Booking, error)
+	ListReschedules(ctx context.Context, id uuid.UUID) ([]domain.BookingReschedule, error)
+
+	CreateBlock(ctx context.Context, in CreateBlockInput) (domain.Booking, error)
+	ListBlocksByDate(ctx context.Context, date time.Time) ([]domain.Booking, error)
+	DeleteBlock(ctx context.Context, id uuid.UUID) error
 }
 
 type Rules struct {
 	Location          *time.Location // Europe/Moscow
 	BookingWindowDays int            // 90
-	WorkStartHHMM     string         // "09:00"
-	WorkEndHHMM       string         // "18:00"
-	SlotMinutes       int            // 30
-	MaxSessionMinutes int            // 180 (кратно 30)
+	Schedule          WeeklySchedule // день без интервалов — выходной
+	SlotMinutes       int            // одно из AllowedSlotMinutes
+	MaxSessionMinutes int            // 180 (кратно SlotMinutes)
+
+	// Буферы до/после клиентской записи (уборка, смена декораций); не обязаны быть кратны слоту.
+	BufferBeforeMinutes int
+	BufferAfterMinutes  int
+
+	MinLeadTime       time.Duration // минимальный запас до начала записи; 0 — только не в прошлом
+	SameDayCutoffHHMM string        // после этого времени запись на сегодня закрыта; "" — без ограничения
+
+	// Режим подтверждения: новая запись создаётся в состоянии pending и держит время ApprovalHoldTime
+	// (но не дольше начала сеанса). Услуга может переопределить ApprovalRequired.
+	ApprovalRequired bool
+	ApprovalHoldTime time.Duration
+
+	// HoldTTL — сколько держится слот, удержанный клиентом на время оформления записи.
+	HoldTTL time.Duration
+
+	// Лимиты удержаний с одного адреса: не больше HoldRateLimit за HoldRateWindow
+	// и не больше MaxActiveHolds действующих одновременно.
+	HoldRateLimit  int
+	HoldRateWindow time.Duration
+	MaxActiveHolds int
+}
+
+type WorkInterval struct {
+	StartHHMM string // "10:00"
+	EndHHMM   string // "14:00"; "24:00" — до конца суток
+}
+
+// WeeklySchedule — рабочие интервалы по дням недели; день без интервалов считается выходным.
+type WeeklySchedule map[time.Weekday][]WorkInterval
+
+type FreeSlotsQuery struct {
+	Date time.Time
+
+	// Длительность сеанса; если не задана ни она, ни услуга — один слот.
+	DurationMinutes int
+	ServiceID       *uuid.UUID
+}
+
+type FreeSlots struct {
+	DurationMinutes int
+	Starts          []string // HH:MM
 }
 
 type CreateBookingInput struct {
 	Date            time.Time
 	StartTimeHHMM   string
-	DurationMinutes int
+	DurationMinutes int // 0 — взять из услуги
+
+	// ServiceID — услуга из каталога; задаёт длительность и цену записи.
+	ServiceID *uuid.UUID
 
 	ClientName  string
 	ClientPhone string
 	Comment     *string
+
+	// HoldToken — токен удержания этого же интервала (CreateHold); пусто — без удержания.
+	HoldToken string
+}
+
+type Deps struct {
+	Bookings repository.BookingRepository
+	Closures repository.ClosureRepository
+	Services repository.StudioServiceRepository
+
+	Logger *slog.Logger
 }
 
 type svc struct {
-	repo  repository.BookingRepository
-	rules compiledRules
-	log   *slog.Logger
+	repo     repository.BookingRepository
+	closures repository.ClosureRepository
+	services repository.StudioServiceRepository
+	rules    compiledRules
+	log      *slog.Logger
 }
 
 type compiledRules struct {
 	loc               *time.Location
 	bookingWindowDays int
-	workStartMin      int
-	workEndMin        int
+	schedule          [7][]minuteRange
 	slotMinutes       int
 	maxSessionMinutes int
+	bufferBefore      time.Duration
+	bufferAfter       time.Duration
+	minLeadTime       time.Duration
+	sameDayCutoff     int // минуты от начала дня; -1 — без ограничения
+	approvalRequired  bool
+	approvalHold      time.Duration
+	holdTTL           time.Duration
+	holdRateLimit     int
+	holdRateWindow    time.Duration
+	maxActiveHolds    int
+}
+
+// minuteRange — полуинтервал [start, end) в минутах от начала дня.
+type minuteRange struct {
+	start int
+	end   int
 }
 
 var phoneRe = regexp.MustCompile(`^\+7\d{10}$`)
 
-func New(repo repository.BookingRepository, r Rules, log *slog.Logger) (Service, error) {
-	if repo == nil {
+const studioClosedMessage = "Студия закрыта в этот день"
+
+// AllowedSlotMinutes — поддерживаемые размеры шага сетки слотов.
+var AllowedSlotMinutes = []int{10, 15, 20, 30, 60}
+
+func New(d Deps, r Rules) (Service, error) {
+	if d.Bookings == nil {
 		return nil, fmt.Errorf("booking service: repo is nil")
 	}
+	if d.Closures == nil {
+		return nil, fmt.Errorf("booking service: closures repo is nil")
+	}
+	if d.Services == nil {
+		return nil, fmt.Errorf("booking service: services repo is nil")
+	}
 	if r.Location == nil {
 		return nil, fmt.Errorf("booking service: Rules.Location is nil")
 	}
+	log := d.Logger
 	if log == nil {
 		log = slog.Default()
 	}
 	log = log.With("component", "booking_service")
 
-	ws, err := parseHHMMToMinutes(r.WorkStartHHMM)
-	if err != nil {
-		return nil, fmt.Errorf("booking service: invalid WorkStartHHMM: %w", err)
-	}
-	we, err := parseHHMMToMinutes(r.WorkEndHHMM)
-	if err != nil {
-		return nil, fmt.Errorf("booking service: invalid WorkEndHHMM: %w", err)
-	}
-	if we <= ws {
-		return nil, fmt.Errorf("booking service: WorkEnd must be after WorkStart")
-	}
-	if r.SlotMinutes != 30 {
-		return nil, fmt.Errorf("booking service: SlotMinutes must be 30 in MVP")
+	if !slices.Contains(AllowedSlotMinutes, r.SlotMinutes) {
+		return nil, fmt.Errorf("booking service: SlotMinutes must be one of %v", AllowedSlotMinutes)
 	}
 	if r.BookingWindowDays <= 0 {
 		return nil, fmt.Errorf("booking service: BookingWindowDays must be > 0")
@@ -90,79 +198,146 @@ func New(repo repository.BookingRepository, r Rules, log *slog.Logger) (Service,
 	if r.MaxSessionMinutes < r.SlotMinutes || r.MaxSessionMinutes%r.SlotMinutes != 0 {
 		return nil, fmt.Errorf("booking service: MaxSessionMinutes must be multiple of slot and >= slot")
 	}
+	if r.BufferBeforeMinutes < 0 || r.BufferAfterMinutes < 0 {
+		return nil, fmt.Errorf("booking service: buffers must be >= 0")
+	}
+
+	if r.MinLeadTime < 0 {
+		return nil, fmt.Errorf("booking service: MinLeadTime must be >= 0")
+	}
+	if r.ApprovalHoldTime <= 0 {
+		return nil, fmt.Errorf("booking service: ApprovalHoldTime must be > 0")
+	}
+	if r.HoldTTL <= 0 {
+		return nil, fmt.Errorf("booking service: HoldTTL must be > 0")
+	}
+	if r.HoldRateLimit <= 0 || r.HoldRateWindow <= 0 || r.MaxActiveHolds <= 0 {
+		return nil, fmt.Errorf("booking service: hold limits must be > 0")
+	}
+	sameDayCutoff := -1
+	if r.SameDayCutoffHHMM != "" {
+		m, err := parseHHMMToMinutes(r.SameDayCutoffHHMM)
+		if err != nil {
+			return nil, fmt.Errorf("booking service: invalid SameDayCutoffHHMM %q: %w", r.SameDayCutoffHHMM, err)
+		}
+		sameDayCutoff = m
+	}
+
+	schedule, err := compileSchedule(r.Schedule, r.SlotMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("booking service: %w", err)
+	}
 
 	return &svc{
-		repo: repo,
-		log:  log,
+		repo:     d.Bookings,
+		closures: d.Closures,
+		services: d.Services,
+		log:      log,
 		rules: compiledRules{
 			loc:               r.Location,
 			bookingWindowDays: r.BookingWindowDays,
-			workStartMin:      ws,
-			workEndMin:        we,
+			schedule:          schedule,
 			slotMinutes:       r.SlotMinutes,
 			maxSessionMinutes: r.MaxSessionMinutes,
+			bufferBefore:      time.Duration(r.BufferBeforeMinutes) * time.Minute,
+			bufferAfter:       time.Duration(r.BufferAfterMinutes) * time.Minute,
+			minLeadTime:       r.MinLeadTime,
+			sameDayCutoff:     sameDayCutoff,
+			approvalRequired:  r.ApprovalRequired,
+			approvalHold:      r.ApprovalHoldTime,
+			holdTTL:           r.HoldTTL,
+			holdRateLimit:     r.HoldRateLimit,
+			holdRateWindow:    r.HoldRateWindow,
+			maxActiveHolds:    r.MaxActiveHolds,
 		},
 	}, nil
 }
 
-func (s *svc) GetFreeSlots(ctx context.Context, date time.Time) ([]string, error) {
+func (s *svc) GetFreeSlots(ctx context.Context, q FreeSlotsQuery) (FreeSlots, error) {
+	date := q.Date
 	dateLocal := s.dateOnlyLocal(date)
-	s.log.Debug("GetFreeSlots start", "date", dateLocal.Format("2006-01-02"))
+	s.log.Debug("GetFreeSlots start", "date", dateLocal.Format("2006-01-02"), "duration_min", q.DurationMinutes)
 
-	if err := s.validatePublicDate(date); err != nil {
+	if err := s.validatePublicDate(ctx, date); err != nil {
 		s.log.Info("GetFreeSlots validation failed", "date", dateLocal.Format("2006-01-02"), "err", err)
-		return nil, err
+		return FreeSlots{}, err
+	}
+
+	durationMinutes, _, verr, err := s.resolveDuration(ctx, q.DurationMinutes, q.ServiceID, s.rules.slotMinutes, domain.ValidationError{})
+	if err != nil {
+		s.log.Error("GetFreeSlots resolveDuration failed", "err", err)
+		return FreeSlots{}, err
+	}
+	if !verr.IsEmpty() {
+		s.log.Info("GetFreeSlots validation failed", "date", dateLocal.Format("2006-01-02"), "err", verr)
+		return FreeSlots{}, verr
 	}
 
 	dayStartLocal, dayEndLocal := s.dayBoundsLocal(date)
 
 	bookings, err := s.repo.ListByRange(ctx, repository.ListBookingsByRangeParams{
-		RangeStart: dayStartLocal.UTC(),
-		RangeEnd:   dayEndLocal.UTC(),
+		RangeStart: dayStartLocal.Add(-s.rules.bufferBefore).UTC(),
+		RangeEnd:   dayEndLocal.Add(s.rules.bufferAfter).UTC(),
 	})
 	if err != nil {
 		s.log.Error("GetFreeSlots repo.ListByRange failed", "date", dateLocal.Format("2006-01-02"), "err", err)
-		return nil, err
+		return FreeSlots{}, err
 	}
 
-	nowLocal := time.Now().In(s.rules.loc)
-	reqDayLocal := s.dateOnlyLocal(date)
-	todayLocal := s.dateOnlyLocal(nowLocal)
+	out := s.freeStarts(dateLocal, time.Now().In(s.rules.loc), durationMinutes, bookings)
 
-	workStartLocal := dayStartLocal.Add(time.Duration(s.rules.workStartMin) * time.Minute)
-	workEndLocal := dayStartLocal.Add(time.Duration(s.rules.workEndMin) * time.Minute)
+	s.log.Debug("GetFreeSlots done", "date", dateLocal.Format("2006-01-02"), "free_slots", len(out))
+	return FreeSlots{DurationMinutes: durationMinutes, Starts: out}, nil
+}
 
+// freeStarts возвращает начала (HH:MM), с которых сеанс durationMinutes вместе с буферами
+// помещается в рабочий интервал дня и не пересекается с bookings.
+func (s *svc) freeStarts(dateLocal, nowLocal time.Time, durationMinutes int, bookings []domain.Booking) []string {
 	out := make([]string, 0, 32)
-	for slotLocal := workStartLocal; slotLocal.Add(time.Duration(s.rules.slotMinutes) * time.Minute).Before(workEndLocal.Add(time.Nanosecond)); slotLocal = slotLocal.Add(time.Duration(s.rules.slotMinutes) * time.Minute) {
-		if reqDayLocal.Equal(todayLocal) && slotLocal.Before(nowLocal) {
-			continue
-		}
+	if s.sameDayClosed(dateLocal, nowLocal) {
+		return out
+	}
+	earliestLocal := nowLocal.Add(s.rules.minLeadTime)
 
-		slotStartUTC := slotLocal.UTC()
-		slotEndUTC := slotStartUTC.Add(time.Duration(s.rules.slotMinutes) * time.Minute)
+	dayStartLocal, _ := s.dayBoundsLocal(dateLocal)
+	slot := time.Duration(s.rules.slotMinutes) * time.Minute
+	session := time.Duration(durationMinutes) * time.Minute
 
-		if isSlotFreeUTC(slotStartUTC, slotEndUTC, bookings) {
-			out = append(out, slotLocal.Format("15:04"))
+	for _, wr := range s.workRanges(dateLocal) {
+		workStartLocal := dayStartLocal.Add(time.Duration(wr.start) * time.Minute)
+		workEndLocal := dayStartLocal.Add(time.Duration(wr.end) * time.Minute)
+
+		// Весь сеанс должен уместиться в тот же рабочий интервал, что и начало.
+		for slotLocal := workStartLocal; !slotLocal.Add(session).After(workEndLocal); slotLocal = slotLocal.Add(slot) {
+			if slotLocal.Before(earliestLocal) {
+				continue
+			}
+
+			slotStartUTC := slotLocal.UTC()
+			slotEndUTC := slotStartUTC.Add(session)
+
+			if isSlotFreeUTC(slotStartUTC.Add(-s.rules.bufferBefore), slotEndUTC.Add(s.rules.bufferAfter), bookings) {
+				out = append(out, slotLocal.Format("15:04"))
+			}
 		}
 	}
-
-	s.log.Debug("GetFreeSlots done", "date", dateLocal.Format("2006-01-02"), "free_slots", len(out))
-	return out, nil
+	return out
 }
 
+// isSlotFreeUTC проверяет интервал (уже расширенный буферами) против занятых интервалов активных записей.
 func isSlotFreeUTC(slotStartUTC, slotEndUTC time.Time, bookings []domain.Booking) bool {
 	for _, b := range bookings {
-		if b.Status != domain.BookingStatusActive {
+		if !b.OccupiesTime() {
 			continue
 		}
-		if b.StartAt.Before(slotEndUTC) && slotStartUTC.Before(b.EndAt) {
+		if b.OccupiedStartAt.Before(slotEndUTC) && slotStartUTC.Before(b.OccupiedEndAt) {
 			return false
 		}
 	}
 	return true
 }
 
-func (s *svc) CreateBooking(ctx context.Context, in CreateBookingInput) (domain.Booking, error) {
+func (s *svc) CreateBooking(ctx context.Context, in CreateBookingInput) (domain.Booking, string, error) {
 	reqDateLocal := s.dateOnlyLocal(in.Date)
 	s.log.Info("CreateBooking start",
 		"date", reqDateLocal.Format("2006-01-02"),
@@ -172,9 +347,9 @@ func (s *svc) CreateBooking(ctx context.Context, in CreateBookingInput) (domain.
 
 	verr := domain.ValidationError{}
 
-	if err := s.validatePublicDate(in.Date); err != nil {
+	if err := s.validatePublicDate(ctx, in.Date); err != nil {
 		s.log.Info("CreateBooking validation failed (date)", "date", reqDateLocal.Format("2006-01-02"), "err", err)
-		return domain.Booking{}, err
+		return domain.Booking{}, "", err
 	}
 
 	if in.ClientName == "" {
@@ -184,58 +359,76 @@ func (s *svc) CreateBooking(ctx context.Context, in CreateBookingInput) (domain.
 		verr = verr.Add("phone", "Телефон должен быть в формате +7XXXXXXXXXX")
 	}
 
-	startMin, err := parseHHMMToMinutes(in.StartTimeHHMM)
-	if err != nil {
-		verr = verr.Add("start_time", "Время должно быть в формате HH:MM")
-	} else {
-		if startMin%s.rules.slotMinutes != 0 {
-			verr = verr.Add("start_time", "Время должно быть кратно 30 минутам")
-		}
-		if startMin < s.rules.workStartMin || startMin >= s.rules.workEndMin {
-			verr = verr.Add("start_time", "Время должно быть в пределах рабочего дня")
-		}
-	}
+	startMin, workRange, verr := s.parseStart(reqDateLocal, in.StartTimeHHMM, verr)
 
-	if in.DurationMinutes < s.rules.slotMinutes || in.DurationMinutes%s.rules.slotMinutes != 0 {
-		verr = verr.Add("duration_minutes", "Длительность должна быть кратна 30 минутам и не меньше 30")
-	} else if in.DurationMinutes > s.rules.maxSessionMinutes {
-		verr = verr.Add("duration_minutes", "Длительность превышает допустимый максимум")
+	durationMinutes, service, verr, err := s.resolveDuration(ctx, in.DurationMinutes, in.ServiceID, 0, verr)
+	if err != nil {
+		s.log.Error("CreateBooking resolveDuration failed", "err", err)
+		return domain.Booking{}, "", err
 	}
+	in.DurationMinutes = durationMinutes
 
 	if !verr.IsEmpty() {
 		s.log.Info("CreateBooking validation failed", "date", reqDateLocal.Format("2006-01-02"), "err", verr)
-		return domain.Booking{}, verr
+		return domain.Booking{}, "", verr
 	}
 
-	dayStartLocal, _ := s.dayBoundsLocal(in.Date)
-	startLocal := dayStartLocal.Add(time.Duration(startMin) * time.Minute)
-	endLocal := startLocal.Add(time.Duration(in.DurationMinutes) * time.Minute)
+	startLocal, endLocal := s.sessionBounds(reqDateLocal, startMin, in.DurationMinutes)
+	if err := s.checkSessionTime(reqDateLocal, startLocal, endLocal, workRange, true); err != nil {
+		s.log.Info("CreateBooking validation failed (time)", "date", reqDateLocal.Format("2006-01-02"), "err", err)
+		return domain.Booking{}, "", err
+	}
 
-	workEndLocal := dayStartLocal.Add(time.Duration(s.rules.workEndMin) * time.Minute)
-	if endLocal.After(workEndLocal) {
-		err := domain.ValidationError{}.Add("duration_minutes", "Интервал выходит за пределы рабочего времени")
-		s.log.Info("CreateBooking validation failed (work hours)", "date", reqDateLocal.Format("2006-01-02"), "err", err)
-		return domain.Booking{}, err
+	startUTC := startLocal.UTC()
+	endUTC := endLocal.UTC()
+
+	if in.HoldToken != "" {
+		if err := s.checkHold(ctx, in.HoldToken, startUTC, endUTC); err != nil {
+			s.log.Info("CreateBooking hold check failed", "date", reqDateLocal.Format("2006-01-02"), "err", err)
+			return domain.Booking{}, "", err
+		}
 	}
 
-	nowLocal := time.Now().In(s.rules.loc)
-	todayLocal := s.dateOnlyLocal(nowLocal)
-	if reqDateLocal.Equal(todayLocal) && startLocal.Before(nowLocal) {
-		err := domain.ValidationError{}.Add("start_time", "Нельзя записаться на прошедшее время")
-		s.log.Info("CreateBooking validation failed (past time)", "date", reqDateLocal.Format("2006-01-02"), "err", err)
-		return domain.Booking{}, err
+	token, err := newSecretToken()
+	if err != nil {
+		s.log.Error("CreateBooking newSecretToken failed", "err", err)
+		return domain.Booking{}, "", err
 	}
 
-	startUTC := startLocal.UTC()
-	endUTC := endLocal.UTC()
+	params := repository.CreateBookingParams{
+		StartAt:         startUTC,
+		EndAt:           endUTC,
+		OccupiedStartAt: startUTC.Add(-s.rules.bufferBefore),
+		OccupiedEndAt:   endUTC.Add(s.rules.bufferAfter),
+		ClientName:      in.ClientName,
+		ClientPhone:     in.ClientPhone,
+		Comment:         in.Comment,
+		ManageTokenHash: hashSecretToken(token),
+		StudioDate:      reqDateLocal,
+	}
+	if in.HoldToken != "" {
+		params.HoldTokenHash = hashSecretToken(in.HoldToken)
+	}
+	if service != nil {
+		params.ServiceID = &service.ID
+		params.ServiceName = &service.Name
+		params.PriceRub = &service.PriceRub
+	}
+	if s.requiresApproval(service) {
+		expiresAt := time.Now().UTC().Add(s.rules.approvalHold)
+		if startUTC.Before(expiresAt) {
+			expiresAt = startUTC
+		}
+		params.Status = domain.BookingStatusPending
+		params.PendingExpiresAt = &expiresAt
+	}
 
-	created, err := s.repo.Create(ctx, repository.CreateBookingParams{
-		StartAt:     startUTC,
-		EndAt:       endUTC,
-		ClientName:  in.ClientName,
-		ClientPhone: in.ClientPhone,
-		Comment:     in.Comment,
-	})
+	created, err := s.repo.Create(ctx, params)
+	if in.HoldToken != "" && errors.Is(err, domain.ErrNotFound) {
+		// Удержание истекло или выкуплено параллельным запросом между проверкой и вставкой.
+		err = domain.ValidationError{}.Add("hold_token", holdExpiredMessage)
+	}
+	err = closedDateError(err)
 	if err != nil {
 		s.log.Info("CreateBooking failed",
 			"date", reqDateLocal.Format("2006-01-02"),
@@ -243,16 +436,17 @@ func (s *svc) CreateBooking(ctx context.Context, in CreateBookingInput) (domain.
 			"duration_min", in.DurationMinutes,
 			"err", err,
 		)
-		return domain.Booking{}, err
+		return domain.Booking{}, "", err
 	}
 
 	s.log.Info("CreateBooking success",
 		"booking_id", created.ID.String(),
+		"status", string(created.Status),
 		"date", reqDateLocal.Format("2006-01-02"),
 		"start_time", in.StartTimeHHMM,
 		"duration_min", in.DurationMinutes,
 	)
-	return created, nil
+	return created, token, nil
 }
 
 func (s *svc) ListBookingsByDate(ctx context.Context, date time.Time) ([]domain.Booking, error) {
@@ -269,8 +463,15 @@ func (s *svc) ListBookingsByDate(ctx context.Context, date time.Time) ([]domain.
 		return nil, err
 	}
 
-	s.log.Debug("ListBookingsByDate done", "date", dateLocal.Format("2006-01-02"), "items", len(items))
-	return items, nil
+	out := make([]domain.Booking, 0, len(items))
+	for _, b := range items {
+		if b.IsClient() {
+			out = append(out, b)
+		}
+	}
+
+	s.log.Debug("ListBookingsByDate done", "date", dateLocal.Format("2006-01-02"), "items", len(out))
+	return out, nil
 }
 
 func (s *svc) GetBooking(ctx context.Context, id uuid.UUID) (domain.Booking, error) {
@@ -281,30 +482,24 @@ func (s *svc) GetBooking(ctx context.Context, id uuid.UUID) (domain.Booking, err
 		s.log.Info("GetBooking failed", "booking_id", id.String(), "err", err)
 		return domain.Booking{}, err
 	}
+	if !b.IsClient() {
+		s.log.Info("GetBooking failed", "booking_id", id.String(), "err", "not a client booking")
+		return domain.Booking{}, domain.ErrNotFound
+	}
 
 	s.log.Debug("GetBooking success", "booking_id", id.String(), "status", string(b.Status))
 	return b, nil
 }
 
 func (s *svc) CancelBooking(ctx context.Context, id uuid.UUID, reason *string) (domain.Booking, error) {
-	s.log.Info("CancelBooking start", "booking_id", id.String())
-
-	nowUTC := time.Now().UTC()
-	b, err := s.repo.Cancel(ctx, repository.CancelBookingParams{
-		ID:     id,
-		NowUTC: nowUTC,
+	return s.adminTransition(ctx, "CancelBooking", id, repository.UpdateBookingStatusParams{
+		To:     domain.BookingStatusCancelled,
 		Reason: reason,
-	})
-	if err != nil {
-		s.log.Info("CancelBooking failed", "booking_id", id.String(), "err", err)
-		return domain.Booking{}, err
-	}
-
-	s.log.Info("CancelBooking success", "booking_id", id.String(), "status", string(b.Status))
-	return b, nil
+		Source: domain.BookingActorAdmin,
+	}, nil)
 }
 
-func (s *svc) validatePublicDate(date time.Time) error {
+func (s *svc) validatePublicDate(ctx context.Context, date time.Time) error {
 	d := s.dateOnlyLocal(date)
 	nowLocal := time.Now().In(s.rules.loc)
 	today := s.dateOnlyLocal(nowLocal)
@@ -318,12 +513,198 @@ func (s *svc) validatePublicDate(date time.Time) error {
 		return domain.ValidationError{}.Add("date", "Дата должна быть в пределах окна записи")
 	}
 
-	switch d.Weekday() {
-	case time.Saturday, time.Sunday:
-		return domain.ValidationError{}.Add("date", "Доступны только будние дни")
-	default:
+	if len(s.workRanges(d)) == 0 {
+		return domain.ValidationError{}.Add("date", "В этот день студия не работает")
+	}
+
+	closures, err := s.closures.ListByRange(ctx, repository.ListClosuresByRangeParams{
+		FromDate: d,
+		ToDate:   d,
+	})
+	if err != nil {
+		s.log.Error("validatePublicDate repo.ListClosures failed", "date", d.Format("2006-01-02"), "err", err)
+		return err
+	}
+	if len(closures) > 0 {
+		return domain.ValidationError{}.Add("date", studioClosedMessage)
+	}
+
+	return nil
+}
+
+// closedDateError переводит ErrStudioClosed (закрытие создано после validatePublicDate) в ту же ошибку валидации.
+func closedDateError(err error) error {
+	if errors.Is(err, domain.ErrStudioClosed) {
+		return domain.ValidationError{}.Add("date", studioClosedMessage)
+	}
+	return err
+}
+
+// parseStart проверяет время начала по сетке слотов и рабочим интервалам дня; ошибки дописываются в verr.
+func (s *svc) parseStart(dateLocal time.Time, startHHMM string, verr domain.ValidationError) (int, minuteRange, domain.ValidationError) {
+	startMin, err := parseHHMMToMinutes(startHHMM)
+	if err != nil {
+		return 0, minuteRange{}, verr.Add("start_time", "Время должно быть в формате HH:MM")
+	}
+	if startMin%s.rules.slotMinutes != 0 {
+		verr = verr.Add("start_time", s.startGridMessage())
+	}
+	wr, ok := s.workRangeAt(dateLocal, startMin)
+	if !ok {
+		verr = verr.Add("start_time", "Время должно быть в пределах рабочего дня")
+	}
+	return startMin, wr, verr
+}
+
+func (s *svc) sessionBounds(dateLocal time.Time, startMin, durationMinutes int) (time.Time, time.Time) {
+	dayStartLocal, _ := s.dayBoundsLocal(dateLocal)
+	startLocal := dayStartLocal.Add(time.Duration(startMin) * time.Minute)
+	return startLocal, startLocal.Add(time.Duration(durationMinutes) * time.Minute)
+}
+
+// checkSessionTime проверяет интервал целиком: рабочее время и не в прошлом; для клиента (public)
+// ещё закрытие записи на сегодня и минимальный запас до начала — администратор ими не ограничен.
+func (s *svc) checkSessionTime(dateLocal, startLocal, endLocal time.Time, workRange minuteRange, public bool) error {
+	dayStartLocal, _ := s.dayBoundsLocal(dateLocal)
+	workEndLocal := dayStartLocal.Add(time.Duration(workRange.end) * time.Minute)
+	if endLocal.After(workEndLocal) {
+		return domain.ValidationError{}.Add("duration_minutes", "Интервал выходит за пределы рабочего времени")
+	}
+
+	nowLocal := time.Now().In(s.rules.loc)
+	if startLocal.Before(nowLocal) {
+		return domain.ValidationError{}.Add("start_time", "Нельзя записаться на прошедшее время")
+	}
+	if !public {
 		return nil
 	}
+	if s.sameDayClosed(dateLocal, nowLocal) {
+		return domain.ValidationError{}.Add("date", "Запись на сегодня уже закрыта")
+	}
+	if startLocal.Before(nowLocal.Add(s.rules.minLeadTime)) {
+		return domain.ValidationError{}.Add("start_time", fmt.Sprintf("Записаться можно не позднее чем за %s до начала", formatLeadTime(s.rules.minLeadTime)))
+	}
+	return nil
+}
+
+func (s *svc) workRanges(dateLocal time.Time) []minuteRange {
+	return s.rules.schedule[dateLocal.Weekday()]
+}
+
+// workRangeAt возвращает рабочий интервал дня, в который попадает минута startMin.
+func (s *svc) workRangeAt(dateLocal time.Time, startMin int) (minuteRange, bool) {
+	for _, wr := range s.workRanges(dateLocal) {
+		if startMin >= wr.start && startMin < wr.end {
+			return wr, true
+		}
+	}
+	return minuteRange{}, false
+}
+
+func compileSchedule(ws WeeklySchedule, slotMinutes int) ([7][]minuteRange, error) {
+	var out [7][]minuteRange
+	open := 0
+
+	for day, intervals := range ws {
+		if day < time.Sunday || day > time.Saturday {
+			return out, fmt.Errorf("invalid weekday %d in Schedule", day)
+		}
+
+		ranges := make([]minuteRange, 0, len(intervals))
+		for _, iv := range intervals {
+			start, err := parseHHMMToMinutes(iv.StartHHMM)
+			if err != nil {
+				return out, fmt.Errorf("%s: invalid StartHHMM %q: %w", day, iv.StartHHMM, err)
+			}
+			end, err := parseEndHHMMToMinutes(iv.EndHHMM)
+			if err != nil {
+				return out, fmt.Errorf("%s: invalid EndHHMM %q: %w", day, iv.EndHHMM, err)
+			}
+			if end <= start {
+				return out, fmt.Errorf("%s: interval end must be after start", day)
+			}
+			if start%slotMinutes != 0 {
+				return out, fmt.Errorf("%s: interval start %s must align to slot grid", day, iv.StartHHMM)
+			}
+			if end%slotMinutes != 0 {
+				return out, fmt.Errorf("%s: interval end %s must align to slot grid", day, iv.EndHHMM)
+			}
+			ranges = append(ranges, minuteRange{start: start, end: end})
+		}
+
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+		for i := 1; i < len(ranges); i++ {
+			if ranges[i].start < ranges[i-1].end {
+				return out, fmt.Errorf("%s: intervals overlap", day)
+			}
+		}
+
+		out[day] = ranges
+		open += len(ranges)
+	}
+
+	if open == 0 {
+		return out, fmt.Errorf("Schedule has no working intervals")
+	}
+	return out, nil
+}
+
+// resolveDuration определяет длительность по явному значению и/или услуге каталога.
+// Если не задано ни то, ни другое, используется def (0 — ошибка валидации).
+// Ошибки валидации дописываются в verr; err — только ошибки хранилища.
+func (s *svc) resolveDuration(ctx context.Context, durationMinutes int, serviceID *uuid.UUID, def int, verr domain.ValidationError) (int, *domain.StudioService, domain.ValidationError, error) {
+	var service *domain.StudioService
+	if serviceID != nil {
+		item, err := s.services.GetByID(ctx, *serviceID)
+		switch {
+		case errors.Is(err, domain.ErrNotFound) || (err == nil && !item.IsActive):
+			return 0, nil, verr.Add("service_id", "Услуга не найдена"), nil
+		case err != nil:
+			return 0, nil, verr, err
+		case durationMinutes != 0 && durationMinutes != item.DurationMinutes:
+			return 0, nil, verr.Add("duration_minutes", "Длительность не совпадает с длительностью услуги"), nil
+		}
+		durationMinutes = item.DurationMinutes
+		service = &item
+	}
+
+	if durationMinutes == 0 {
+		durationMinutes = def
+	}
+
+	switch {
+	case durationMinutes == 0:
+		verr = verr.Add("duration_minutes", "Укажите длительность или услугу")
+	case durationMinutes < s.rules.slotMinutes || durationMinutes%s.rules.slotMinutes != 0:
+		verr = verr.Add("duration_minutes", s.durationGridMessage())
+	case durationMinutes > s.rules.maxSessionMinutes:
+		verr = verr.Add("duration_minutes", "Длительность превышает допустимый максимум")
+	}
+	return durationMinutes, service, verr, nil
+}
+
+// sameDayClosed — запись на сегодняшний день закрыта, если уже наступило SameDayCutoff.
+func (s *svc) sameDayClosed(dateLocal, nowLocal time.Time) bool {
+	if s.rules.sameDayCutoff < 0 || !dateLocal.Equal(s.dateOnlyLocal(nowLocal)) {
+		return false
+	}
+	return nowLocal.Hour()*60+nowLocal.Minute() >= s.rules.sameDayCutoff
+}
+
+func formatLeadTime(d time.Duration) string {
+	m := int(d / time.Minute)
+	if m%60 == 0 {
+		return fmt.Sprintf("%d ч", m/60)
+	}
+	return fmt.Sprintf("%d мин", m)
+}
+
+func (s *svc) startGridMessage() string {
+	return fmt.Sprintf("Время должно быть кратно %d минутам", s.rules.slotMinutes)
+}
+
+func (s *svc) durationGridMessage() string {
+	return fmt.Sprintf("Длительность должна быть кратна %d минутам и не меньше %d", s.rules.slotMinutes, s.rules.slotMinutes)
 }
 
 func (s *svc) dayBoundsLocal(date time.Time) (time.Time, time.Time) {
@@ -352,3 +733,11 @@ func parseHHMMToMinutes(s string) (int, error) {
 	}
 	return hh*60 + mm, nil
 }
+
+// parseEndHHMMToMinutes — конец рабочего интервала: допускает 24:00 (конец суток).
+func parseEndHHMMToMinutes(s string) (int, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	return parseHHMMToMinutes(s)
+}
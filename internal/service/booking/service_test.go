@@ -0,0 +1,52 @@
+package booking
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompileSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		ws      WeeklySchedule
+		wantErr bool
+	}{
+		{"break", WeeklySchedule{time.Monday: {{"09:00", "13:00"}, {"14:00", "18:00"}}}, false},
+		{"adjacent", WeeklySchedule{time.Monday: {{"10:00", "12:00"}, {"12:00", "14:00"}}}, false},
+		{"unsorted", WeeklySchedule{time.Friday: {{"14:00", "18:00"}, {"09:00", "13:00"}}}, false},
+		{"end of day", WeeklySchedule{time.Saturday: {{"18:00", "24:00"}}}, false},
+		{"start 24:00", WeeklySchedule{time.Saturday: {{"24:00", "24:00"}}}, true},
+		{"past end of day", WeeklySchedule{time.Saturday: {{"18:00", "24:30"}}}, true},
+		{"hour 25", WeeklySchedule{time.Monday: {{"09:00", "25:00"}}}, true},
+		{"bad minutes", WeeklySchedule{time.Monday: {{"09:60", "18:00"}}}, true},
+		{"single digit hour", WeeklySchedule{time.Monday: {{"9:00", "18:00"}}}, true},
+		{"end before start", WeeklySchedule{time.Monday: {{"18:00", "09:00"}}}, true},
+		{"empty interval", WeeklySchedule{time.Monday: {{"09:00", "09:00"}}}, true},
+		{"overlap", WeeklySchedule{time.Monday: {{"09:00", "13:00"}, {"12:00", "18:00"}}}, true},
+		{"overlap unsorted", WeeklySchedule{time.Monday: {{"12:00", "18:00"}, {"09:00", "13:00"}}}, true},
+		{"nested", WeeklySchedule{time.Monday: {{"09:00", "18:00"}, {"10:00", "11:00"}}}, true},
+		{"misaligned start", WeeklySchedule{time.Monday: {{"09:15", "18:00"}}}, true},
+		{"misaligned end", WeeklySchedule{time.Monday: {{"09:00", "17:45"}}}, true},
+		{"no working intervals", WeeklySchedule{time.Monday: nil}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileSchedule(tt.ws, 30)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("compileSchedule: err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompileScheduleEndOfDay(t *testing.T) {
+	out, err := compileSchedule(WeeklySchedule{time.Saturday: {{"18:00", "24:00"}}}, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []minuteRange{{start: 18 * 60, end: 24 * 60}}
+	if got := out[time.Saturday]; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("saturday = %v, want %v", got, want)
+	}
+}
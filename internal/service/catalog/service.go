@@ -0,0 +1,180 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type Service interface {
+	ListServices(ctx context.Context, activeOnly bool) ([]domain.StudioService, error)
+	CreateService(ctx context.Context, in ServiceInput) (domain.StudioService, error)
+	UpdateService(ctx context.Context, id uuid.UUID, in ServiceInput) (domain.StudioService, error)
+	DeleteService(ctx context.Context, id uuid.UUID) error
+}
+
+type ServiceInput struct {
+	Name            string
+	Description     *string
+	DurationMinutes int
+	PriceRub        int
+	IsActive        bool
+
+	RequiresApproval *bool // nil — как в общей настройке
+}
+
+type Deps struct {
+	Services repository.StudioServiceRepository
+
+	// Ограничения длительности — те же, что у записи.
+	SlotMinutes       int
+	MaxSessionMinutes int
+
+	Logger *slog.Logger
+}
+
+type svc struct {
+	repo              repository.StudioServiceRepository
+	slotMinutes       int
+	maxSessionMinutes int
+	log               *slog.Logger
+}
+
+const (
+	maxNameLen        = 100
+	maxDescriptionLen = 1000
+)
+
+func New(d Deps) (Service, error) {
+	if d.Services == nil {
+		return nil, fmt.Errorf("catalog service: services repo is nil")
+	}
+	if d.SlotMinutes <= 0 {
+		return nil, fmt.Errorf("catalog service: SlotMinutes must be > 0")
+	}
+	if d.MaxSessionMinutes < d.SlotMinutes {
+		return nil, fmt.Errorf("catalog service: MaxSessionMinutes must be >= SlotMinutes")
+	}
+	log := d.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+	log = log.With("component", "catalog_service")
+
+	return &svc{
+		repo:              d.Services,
+		slotMinutes:       d.SlotMinutes,
+		maxSessionMinutes: d.MaxSessionMinutes,
+		log:               log,
+	}, nil
+}
+
+func (s *svc) ListServices(ctx context.Context, activeOnly bool) ([]domain.StudioService, error) {
+	s.log.Debug("ListServices start", "active_only", activeOnly)
+
+	items, err := s.repo.List(ctx, activeOnly)
+	if err != nil {
+		s.log.Error("ListServices repo.List failed", "err", err)
+		return nil, err
+	}
+
+	s.log.Debug("ListServices done", "items", len(items))
+	return items, nil
+}
+
+func (s *svc) CreateService(ctx context.Context, in ServiceInput) (domain.StudioService, error) {
+	s.log.Info("CreateService start", "name", in.Name, "duration_min", in.DurationMinutes)
+
+	p, err := s.validate(in)
+	if err != nil {
+		s.log.Info("CreateService validation failed", "err", err)
+		return domain.StudioService{}, err
+	}
+
+	created, err := s.repo.Create(ctx, p)
+	if err != nil {
+		s.log.Error("CreateService repo.Create failed", "err", err)
+		return domain.StudioService{}, err
+	}
+
+	s.log.Info("CreateService success", "service_id", created.ID.String())
+	return created, nil
+}
+
+func (s *svc) UpdateService(ctx context.Context, id uuid.UUID, in ServiceInput) (domain.StudioService, error) {
+	s.log.Info("UpdateService start", "service_id", id.String())
+
+	p, err := s.validate(in)
+	if err != nil {
+		s.log.Info("UpdateService validation failed", "service_id", id.String(), "err", err)
+		return domain.StudioService{}, err
+	}
+
+	updated, err := s.repo.Update(ctx, id, p)
+	if err != nil {
+		s.log.Info("UpdateService failed", "service_id", id.String(), "err", err)
+		return domain.StudioService{}, err
+	}
+
+	s.log.Info("UpdateService success", "service_id", id.String())
+	return updated, nil
+}
+
+func (s *svc) DeleteService(ctx context.Context, id uuid.UUID) error {
+	s.log.Info("DeleteService start", "service_id", id.String())
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.log.Info("DeleteService failed", "service_id", id.String(), "err", err)
+		return err
+	}
+
+	s.log.Info("DeleteService success", "service_id", id.String())
+	return nil
+}
+
+func (s *svc) validate(in ServiceInput) (repository.StudioServiceParams, error) {
+	name := strings.TrimSpace(in.Name)
+
+	var description *string
+	if in.Description != nil {
+		if d := strings.TrimSpace(*in.Description); d != "" {
+			description = &d
+		}
+	}
+
+	verr := domain.ValidationError{}
+	if name == "" {
+		verr = verr.Add("name", "Название обязательно")
+	} else if len([]rune(name)) > maxNameLen {
+		verr = verr.Add("name", "Название слишком длинное")
+	}
+	if description != nil && len([]rune(*description)) > maxDescriptionLen {
+		verr = verr.Add("description", "Описание слишком длинное")
+	}
+	if in.DurationMinutes < s.slotMinutes || in.DurationMinutes%s.slotMinutes != 0 {
+		verr = verr.Add("duration_minutes", fmt.Sprintf("Длительность должна быть кратна %d минутам и не меньше %d", s.slotMinutes, s.slotMinutes))
+	} else if in.DurationMinutes > s.maxSessionMinutes {
+		verr = verr.Add("duration_minutes", "Длительность превышает допустимый максимум")
+	}
+	if in.PriceRub < 0 {
+		verr = verr.Add("price_rub", "Цена не может быть отрицательной")
+	}
+	if !verr.IsEmpty() {
+		return repository.StudioServiceParams{}, verr
+	}
+
+	return repository.StudioServiceParams{
+		Name:             name,
+		Description:      description,
+		DurationMinutes:  in.DurationMinutes,
+		PriceRub:         in.PriceRub,
+		IsActive:         in.IsActive,
+		RequiresApproval: in.RequiresApproval,
+	}, nil
+}
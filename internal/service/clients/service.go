@@ -0,0 +1,165 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type Service interface {
+	ListClients(ctx context.Context, q ListQuery) ([]domain.Client, error)
+	GetClient(ctx context.Context, id uuid.UUID) (domain.Client, error)
+	// MergeClients переносит записи дубликатов к основному клиенту; дубликаты пропадают из справочника.
+	MergeClients(ctx context.Context, targetID uuid.UUID, duplicateIDs []uuid.UUID) (domain.Client, error)
+}
+
+type ListQuery struct {
+	// Search — часть имени или телефона; пусто — все клиенты.
+	Search string
+
+	Limit  int // 0 — defaultLimit
+	Offset int
+}
+
+type Deps struct {
+	Clients repository.ClientRepository
+
+	Logger *slog.Logger
+}
+
+type svc struct {
+	repo repository.ClientRepository
+	log  *slog.Logger
+}
+
+const (
+	defaultLimit    = 50
+	maxLimit        = 200
+	maxSearchLen    = 100
+	maxMergeClients = 20
+)
+
+func New(d Deps) (Service, error) {
+	if d.Clients == nil {
+		return nil, fmt.Errorf("clients service: clients repo is nil")
+	}
+	log := d.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+	log = log.With("component", "clients_service")
+
+	return &svc{repo: d.Clients, log: log}, nil
+}
+
+func (s *svc) ListClients(ctx context.Context, q ListQuery) ([]domain.Client, error) {
+	search := strings.TrimSpace(q.Search)
+	s.log.Debug("ListClients start", "search", search, "limit", q.Limit, "offset", q.Offset)
+
+	verr := domain.ValidationError{}
+	if len([]rune(search)) > maxSearchLen {
+		verr = verr.Add("q", "Слишком длинный запрос")
+	}
+	limit := q.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit < 1 || limit > maxLimit {
+		verr = verr.Add("limit", fmt.Sprintf("limit должен быть от 1 до %d", maxLimit))
+	}
+	if q.Offset < 0 {
+		verr = verr.Add("offset", "offset не может быть отрицательным")
+	}
+	if !verr.IsEmpty() {
+		s.log.Info("ListClients validation failed", "err", verr)
+		return nil, verr
+	}
+
+	items, err := s.repo.List(ctx, repository.ListClientsParams{
+		Query:       escapeLike(search),
+		PhoneDigits: phoneDigits(search),
+		Limit:       limit,
+		Offset:      q.Offset,
+	})
+	if err != nil {
+		s.log.Error("ListClients repo.List failed", "err", err)
+		return nil, err
+	}
+
+	s.log.Debug("ListClients done", "items", len(items))
+	return items, nil
+}
+
+func (s *svc) GetClient(ctx context.Context, id uuid.UUID) (domain.Client, error) {
+	s.log.Debug("GetClient start", "client_id", id.String())
+
+	c, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.log.Info("GetClient failed", "client_id", id.String(), "err", err)
+		return domain.Client{}, err
+	}
+
+	return c, nil
+}
+
+func (s *svc) MergeClients(ctx context.Context, targetID uuid.UUID, duplicateIDs []uuid.UUID) (domain.Client, error) {
+	s.log.Info("MergeClients start", "client_id", targetID.String(), "duplicates", len(duplicateIDs))
+
+	verr := domain.ValidationError{}
+	seen := make(map[uuid.UUID]bool, len(duplicateIDs))
+	switch {
+	case len(duplicateIDs) == 0:
+		verr = verr.Add("duplicate_ids", "Укажите хотя бы один дубликат")
+	case len(duplicateIDs) > maxMergeClients:
+		verr = verr.Add("duplicate_ids", fmt.Sprintf("За раз можно слить не больше %d клиентов", maxMergeClients))
+	}
+	for _, id := range duplicateIDs {
+		if id == targetID {
+			verr = verr.Add("duplicate_ids", "Клиента нельзя слить с самим собой")
+			break
+		}
+		if seen[id] {
+			verr = verr.Add("duplicate_ids", "Дубликаты не должны повторяться")
+			break
+		}
+		seen[id] = true
+	}
+	if !verr.IsEmpty() {
+		s.log.Info("MergeClients validation failed", "client_id", targetID.String(), "err", verr)
+		return domain.Client{}, verr
+	}
+
+	c, err := s.repo.Merge(ctx, targetID, duplicateIDs)
+	if err != nil {
+		s.log.Info("MergeClients failed", "client_id", targetID.String(), "err", err)
+		return domain.Client{}, err
+	}
+
+	s.log.Info("MergeClients success", "client_id", targetID.String(), "merged", len(duplicateIDs), "bookings", c.BookingsCount)
+	return c, nil
+}
+
+// phoneDigits — цифры запроса для поиска по телефону; меньше трёх цифр — не телефон.
+func phoneDigits(search string) string {
+	var b strings.Builder
+	for _, r := range search {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() < 3 {
+		return ""
+	}
+	return b.String()
+}
+
+// escapeLike экранирует спецсимволы шаблона ILIKE, чтобы запрос искался как есть.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,154 @@
+package closure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type Service interface {
+	CreateClosure(ctx context.Context, in CreateClosureInput) (domain.Closure, error)
+	ListClosures(ctx context.Context, from, to time.Time) ([]domain.Closure, error)
+	DeleteClosure(ctx context.Context, id uuid.UUID) error
+}
+
+type CreateClosureInput struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Reason    string
+}
+
+type Deps struct {
+	Closures repository.ClosureRepository
+
+	Location *time.Location
+	Logger   *slog.Logger
+}
+
+type svc struct {
+	closures repository.ClosureRepository
+	loc      *time.Location
+	log      *slog.Logger
+}
+
+const maxReasonLen = 300
+
+func New(d Deps) (Service, error) {
+	if d.Closures == nil {
+		return nil, fmt.Errorf("closure service: closures repo is nil")
+	}
+	if d.Location == nil {
+		return nil, fmt.Errorf("closure service: Location is nil")
+	}
+	log := d.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+	log = log.With("component", "closure_service")
+
+	return &svc{
+		closures: d.Closures,
+		loc:      d.Location,
+		log:      log,
+	}, nil
+}
+
+func (s *svc) CreateClosure(ctx context.Context, in CreateClosureInput) (domain.Closure, error) {
+	start := s.dateOnlyLocal(in.StartDate)
+	end := s.dateOnlyLocal(in.EndDate)
+	reason := strings.TrimSpace(in.Reason)
+
+	s.log.Info("CreateClosure start", "start_date", start.Format("2006-01-02"), "end_date", end.Format("2006-01-02"))
+
+	verr := domain.ValidationError{}
+	if end.Before(start) {
+		verr = verr.Add("end_date", "Дата окончания не может быть раньше даты начала")
+	}
+	if reason == "" {
+		verr = verr.Add("reason", "Причина обязательна")
+	} else if len([]rune(reason)) > maxReasonLen {
+		verr = verr.Add("reason", "Причина слишком длинная")
+	}
+	if !verr.IsEmpty() {
+		s.log.Info("CreateClosure validation failed", "err", verr)
+		return domain.Closure{}, verr
+	}
+
+	c, err := s.closures.Create(ctx, repository.CreateClosureParams{
+		StartDate:    start,
+		EndDate:      end,
+		Reason:       reason,
+		BookingsFrom: start.UTC(),
+		BookingsTo:   end.AddDate(0, 0, 1).UTC(),
+	})
+	var conflict domain.BookingsConflictError
+	if errors.As(err, &conflict) {
+		s.log.Info("CreateClosure conflicts with bookings", "start_date", start.Format("2006-01-02"), "end_date", end.Format("2006-01-02"), "err", err)
+		return domain.Closure{}, err
+	}
+	if err != nil {
+		s.log.Error("CreateClosure repo.Create failed", "err", err)
+		return domain.Closure{}, err
+	}
+
+	s.log.Info("CreateClosure success", "closure_id", c.ID.String())
+	return s.toLocal(c), nil
+}
+
+func (s *svc) ListClosures(ctx context.Context, from, to time.Time) ([]domain.Closure, error) {
+	fromLocal := s.dateOnlyLocal(from)
+	toLocal := s.dateOnlyLocal(to)
+	s.log.Debug("ListClosures start", "from", fromLocal.Format("2006-01-02"), "to", toLocal.Format("2006-01-02"))
+
+	if toLocal.Before(fromLocal) {
+		return nil, domain.ValidationError{}.Add("to", "Дата окончания не может быть раньше даты начала")
+	}
+
+	items, err := s.closures.ListByRange(ctx, repository.ListClosuresByRangeParams{
+		FromDate: fromLocal,
+		ToDate:   toLocal,
+	})
+	if err != nil {
+		s.log.Error("ListClosures repo.ListByRange failed", "err", err)
+		return nil, err
+	}
+
+	for i := range items {
+		items[i] = s.toLocal(items[i])
+	}
+
+	s.log.Debug("ListClosures done", "items", len(items))
+	return items, nil
+}
+
+func (s *svc) DeleteClosure(ctx context.Context, id uuid.UUID) error {
+	s.log.Info("DeleteClosure start", "closure_id", id.String())
+
+	if err := s.closures.Delete(ctx, id); err != nil {
+		s.log.Info("DeleteClosure failed", "closure_id", id.String(), "err", err)
+		return err
+	}
+
+	s.log.Info("DeleteClosure success", "closure_id", id.String())
+	return nil
+}
+
+// toLocal переводит даты из БД (полночь UTC) в полночь TZ студии.
+func (s *svc) toLocal(c domain.Closure) domain.Closure {
+	c.StartDate = time.Date(c.StartDate.Year(), c.StartDate.Month(), c.StartDate.Day(), 0, 0, 0, 0, s.loc)
+	c.EndDate = time.Date(c.EndDate.Year(), c.EndDate.Month(), c.EndDate.Day(), 0, 0, 0, 0, s.loc)
+	return c
+}
+
+func (s *svc) dateOnlyLocal(t time.Time) time.Time {
+	tt := t.In(s.loc)
+	return time.Date(tt.Year(), tt.Month(), tt.Day(), 0, 0, 0, 0, s.loc)
+}
@@ -0,0 +1,135 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+)
+
+type Service interface {
+	// Begin занимает ключ под текущий запрос. Если по ключу уже сохранён ответ, он возвращается для повтора.
+	Begin(ctx context.Context, in BeginInput) (*Response, error)
+	Complete(ctx context.Context, scope, key string, resp Response) error
+	Release(ctx context.Context, scope, key string) error
+
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type Options struct {
+	TTL         time.Duration // 24h
+	LockTimeout time.Duration // сколько ждать незавершённый запрос, прежде чем перезанять ключ
+}
+
+type BeginInput struct {
+	// Scope — операция, а для административных операций ещё и тот, кто её вызывает.
+	Scope string
+	Key   string
+
+	// Request — всё, что однозначно описывает запрос (метод, путь, тело); хранится только хэш.
+	Request []byte
+}
+
+type Response struct {
+	StatusCode int
+	Body       []byte
+}
+
+type svc struct {
+	repo repository.IdempotencyRepository
+	opts Options
+	log  *slog.Logger
+}
+
+func New(repo repository.IdempotencyRepository, opts Options, log *slog.Logger) (Service, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("idempotency service: repo is nil")
+	}
+	if opts.TTL <= 0 {
+		return nil, fmt.Errorf("idempotency service: TTL must be > 0")
+	}
+	if opts.LockTimeout <= 0 {
+		return nil, fmt.Errorf("idempotency service: LockTimeout must be > 0")
+	}
+	if log == nil {
+		log = slog.Default()
+	}
+	log = log.With("component", "idempotency_service")
+
+	return &svc{repo: repo, opts: opts, log: log}, nil
+}
+
+func (s *svc) Begin(ctx context.Context, in BeginInput) (*Response, error) {
+	sum := sha256.Sum256(in.Request)
+	hash := hex.EncodeToString(sum[:])
+
+	nowUTC := time.Now().UTC()
+	rec, reserved, err := s.repo.Reserve(ctx, repository.ReserveIdempotencyKeyParams{
+		Scope:       in.Scope,
+		Key:         in.Key,
+		RequestHash: hash,
+		NowUTC:      nowUTC,
+		ExpiresAt:   nowUTC.Add(s.opts.TTL),
+		StaleBefore: nowUTC.Add(-s.opts.LockTimeout),
+	})
+	if err != nil {
+		s.log.Error("Begin repo.Reserve failed", "scope", in.Scope, "err", err)
+		return nil, err
+	}
+	if reserved {
+		s.log.Debug("Begin key reserved", "scope", in.Scope)
+		return nil, nil
+	}
+
+	if rec.RequestHash != hash {
+		err := domain.ValidationError{}.Add("Idempotency-Key", "Ключ уже использован для другого запроса")
+		s.log.Info("Begin key reused with different request", "scope", in.Scope, "err", err)
+		return nil, err
+	}
+	if !rec.IsCompleted() {
+		s.log.Info("Begin key in progress", "scope", in.Scope)
+		return nil, domain.ErrIdempotencyInProgress
+	}
+
+	s.log.Info("Begin replaying stored response", "scope", in.Scope, "status", rec.StatusCode)
+	return &Response{StatusCode: rec.StatusCode, Body: rec.ResponseBody}, nil
+}
+
+func (s *svc) Complete(ctx context.Context, scope, key string, resp Response) error {
+	err := s.repo.Complete(ctx, repository.CompleteIdempotencyKeyParams{
+		Scope:        scope,
+		Key:          key,
+		StatusCode:   resp.StatusCode,
+		ResponseBody: resp.Body,
+		NowUTC:       time.Now().UTC(),
+	})
+	if err != nil {
+		s.log.Error("Complete repo.Complete failed", "scope", scope, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (s *svc) Release(ctx context.Context, scope, key string) error {
+	if err := s.repo.Release(ctx, scope, key); err != nil {
+		s.log.Error("Release repo.Release failed", "scope", scope, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (s *svc) PurgeExpired(ctx context.Context) (int64, error) {
+	n, err := s.repo.DeleteExpired(ctx, time.Now().UTC())
+	if err != nil {
+		s.log.Error("PurgeExpired repo.DeleteExpired failed", "err", err)
+		return 0, err
+	}
+
+	s.log.Debug("PurgeExpired done", "deleted", n)
+	return n, nil
+}
@@ -0,0 +1,230 @@
+package loginguard
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"photannie/internal/domain"
+	"photannie/internal/repository"
+	"photannie/internal/service/admin"
+)
+
+// Metrics — счётчики, которые сервис обновляет; реализуется observability/metrics.
+type Metrics interface {
+	LoginFailed()
+	LoginBlocked()
+	LoginLocked(scope domain.LoginScope)
+}
+
+type Policy struct {
+	MaxFailuresPerAccount int           // 5
+	MaxFailuresPerIP      int           // 20
+	Window                time.Duration // 15m — после стольких минут без неудач счётчик обнуляется
+	LockoutBase           time.Duration // 30s — первая блокировка, дальше удваивается
+	LockoutMax            time.Duration // 15m
+}
+
+type Service interface {
+	// Check возвращает TooManyAttemptsError, если IP или логин сейчас заблокированы.
+	Check(ctx context.Context, ip, username string) error
+
+	// RecordFailure учитывает неудачный вход; если он привёл к блокировке — возвращает TooManyAttemptsError.
+	RecordFailure(ctx context.Context, ip, username string) error
+
+	RecordSuccess(ctx context.Context, ip, username string) error
+
+	ListLockouts(ctx context.Context, limit int) ([]domain.LoginLockout, error)
+
+	PurgeStale(ctx context.Context) (int64, error)
+}
+
+type Deps struct {
+	Attempts repository.LoginAttemptRepository
+	Metrics  Metrics
+	Logger   *slog.Logger
+}
+
+type svc struct {
+	repo    repository.LoginAttemptRepository
+	metrics Metrics
+	policy  Policy
+	log     *slog.Logger
+	now     func() time.Time
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) LoginFailed()                    {}
+func (noopMetrics) LoginBlocked()                   {}
+func (noopMetrics) LoginLocked(_ domain.LoginScope) {}
+
+func New(d Deps, p Policy) (Service, error) {
+	if d.Attempts == nil {
+		return nil, fmt.Errorf("login guard: attempts repo is nil")
+	}
+	if p.MaxFailuresPerAccount <= 0 || p.MaxFailuresPerIP <= 0 {
+		return nil, fmt.Errorf("login guard: max failures must be > 0")
+	}
+	if p.Window <= 0 || p.LockoutBase <= 0 || p.LockoutMax < p.LockoutBase {
+		return nil, fmt.Errorf("login guard: invalid window/lockout durations")
+	}
+	m := d.Metrics
+	if m == nil {
+		m = noopMetrics{}
+	}
+	log := d.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+	log = log.With("component", "login_guard")
+
+	return &svc{
+		repo:    d.Attempts,
+		metrics: m,
+		policy:  p,
+		log:     log,
+		now:     time.Now,
+	}, nil
+}
+
+func (s *svc) Check(ctx context.Context, ip, username string) error {
+	username = admin.NormalizeUsername(username)
+	now := s.now().UTC()
+
+	until, err := s.repo.LockedUntil(ctx, now, ip, username)
+	if err != nil {
+		s.log.Error("Check repo.LockedUntil failed", "err", err)
+		return err
+	}
+	if until.IsZero() {
+		return nil
+	}
+
+	s.metrics.LoginBlocked()
+	s.log.Info("login blocked", "ip", ip, "username", username, "locked_until", until)
+	return domain.TooManyAttemptsError{RetryAfter: until.Sub(now)}
+}
+
+func (s *svc) RecordFailure(ctx context.Context, ip, username string) error {
+	username = admin.NormalizeUsername(username)
+	now := s.now().UTC()
+	s.metrics.LoginFailed()
+
+	var lockedUntil time.Time
+	for _, k := range []struct {
+		scope domain.LoginScope
+		key   string
+		max   int
+	}{
+		{domain.LoginScopeIP, ip, s.policy.MaxFailuresPerIP},
+		{domain.LoginScopeUsername, username, s.policy.MaxFailuresPerAccount},
+	} {
+		if k.key == "" {
+			continue
+		}
+
+		failures, err := s.repo.RegisterFailure(ctx, repository.RegisterLoginFailureParams{
+			Scope:       k.scope,
+			Key:         k.key,
+			NowUTC:      now,
+			WindowStart: now.Add(-s.policy.Window),
+		})
+		if err != nil {
+			s.log.Error("RecordFailure repo.RegisterFailure failed", "scope", string(k.scope), "err", err)
+			return err
+		}
+		if failures < k.max {
+			continue
+		}
+
+		until := now.Add(s.lockoutFor(failures - k.max))
+		l, err := s.repo.Lock(ctx, repository.LockLoginParams{
+			Scope:       k.scope,
+			Key:         k.key,
+			Failures:    failures,
+			LockedUntil: until,
+		})
+		if err != nil {
+			s.log.Error("RecordFailure repo.Lock failed", "scope", string(k.scope), "err", err)
+			return err
+		}
+
+		s.metrics.LoginLocked(k.scope)
+		s.log.Warn("login locked",
+			"scope", string(l.Scope),
+			"key", l.Key,
+			"failures", l.Failures,
+			"locked_until", l.LockedUntil,
+		)
+		if until.After(lockedUntil) {
+			lockedUntil = until
+		}
+	}
+
+	if lockedUntil.IsZero() {
+		return nil
+	}
+	return domain.TooManyAttemptsError{RetryAfter: lockedUntil.Sub(now)}
+}
+
+func (s *svc) RecordSuccess(ctx context.Context, ip, username string) error {
+	username = admin.NormalizeUsername(username)
+
+	if err := s.repo.Reset(ctx, domain.LoginScopeUsername, username); err != nil {
+		s.log.Error("RecordSuccess repo.Reset failed", "scope", "username", "err", err)
+		return err
+	}
+	if err := s.repo.Reset(ctx, domain.LoginScopeIP, ip); err != nil {
+		s.log.Error("RecordSuccess repo.Reset failed", "scope", "ip", "err", err)
+		return err
+	}
+	return nil
+}
+
+const (
+	defaultLockoutsLimit = 50
+	maxLockoutsLimit     = 500
+)
+
+// ListLockouts — журнал блокировок, новые первыми; limit=0 — значение по умолчанию.
+func (s *svc) ListLockouts(ctx context.Context, limit int) ([]domain.LoginLockout, error) {
+	if limit == 0 {
+		limit = defaultLockoutsLimit
+	}
+	if limit < 1 || limit > maxLockoutsLimit {
+		return nil, domain.ValidationError{}.Add("limit", fmt.Sprintf("limit должен быть от 1 до %d", maxLockoutsLimit))
+	}
+
+	items, err := s.repo.ListLockouts(ctx, limit)
+	if err != nil {
+		s.log.Error("ListLockouts repo.ListLockouts failed", "err", err)
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *svc) PurgeStale(ctx context.Context) (int64, error) {
+	now := s.now().UTC()
+	n, err := s.repo.DeleteStale(ctx, now, now.Add(-s.policy.Window))
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		s.log.Info("stale login attempts purged", "count", n)
+	}
+	return n, nil
+}
+
+// lockoutFor — длительность блокировки: LockoutBase * 2^over, но не больше LockoutMax.
+func (s *svc) lockoutFor(over int) time.Duration {
+	d := s.policy.LockoutBase
+	for i := 0; i < over && d < s.policy.LockoutMax; i++ {
+		d *= 2
+	}
+	if d > s.policy.LockoutMax {
+		d = s.policy.LockoutMax
+	}
+	return d
+}
@@ -0,0 +1,164 @@
+package oidclogin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"photannie/internal/domain"
+	"photannie/internal/oidc"
+	"photannie/internal/repository"
+	"photannie/internal/service/admin"
+)
+
+// Provider — часть oidc.Provider, нужная сервису.
+type Provider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, challenge string) (string, error)
+	Exchange(ctx context.Context, code, verifier string) (string, error)
+	VerifyIDToken(ctx context.Context, raw, nonce string) (oidc.Claims, error)
+}
+
+// Flow — одноразовые значения попытки входа; между редиректами хранятся в cookie браузера.
+type Flow struct {
+	URL      string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+type Service interface {
+	Begin(ctx context.Context) (Flow, error)
+
+	// Complete меняет код на ID token и находит администратора по email из списка разрешённых;
+	// любой отказ (токен, неподтверждённый или чужой email) — ErrUnauthorized.
+	Complete(ctx context.Context, code string, f Flow) (domain.Admin, error)
+}
+
+type Deps struct {
+	Provider Provider
+	Admins   repository.AdminRepository
+
+	// AllowedEmails — записи "email" или "email:логин"; без логина берётся часть email до @.
+	AllowedEmails []string
+
+	Logger *slog.Logger
+}
+
+type svc struct {
+	provider Provider
+	admins   repository.AdminRepository
+	allowed  map[string]string // email -> логин
+	log      *slog.Logger
+}
+
+func New(d Deps) (Service, error) {
+	if d.Provider == nil {
+		return nil, fmt.Errorf("oidc login service: provider is nil")
+	}
+	if d.Admins == nil {
+		return nil, fmt.Errorf("oidc login service: admins repo is nil")
+	}
+	allowed, err := ParseAllowedEmails(d.AllowedEmails)
+	if err != nil {
+		return nil, err
+	}
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("oidc login service: allowed emails list is empty")
+	}
+	log := d.Logger
+	if log == nil {
+		log = slog.Default()
+	}
+	log = log.With("component", "oidc_login_service")
+
+	return &svc{provider: d.Provider, admins: d.Admins, allowed: allowed, log: log}, nil
+}
+
+func (s *svc) Begin(ctx context.Context) (Flow, error) {
+	state, err := oidc.RandomString(24)
+	if err != nil {
+		return Flow{}, err
+	}
+	nonce, err := oidc.RandomString(24)
+	if err != nil {
+		return Flow{}, err
+	}
+	verifier, challenge, err := oidc.NewPKCE()
+	if err != nil {
+		return Flow{}, err
+	}
+
+	u, err := s.provider.AuthCodeURL(ctx, state, nonce, challenge)
+	if err != nil {
+		s.log.Error("Begin provider.AuthCodeURL failed", "err", err)
+		return Flow{}, err
+	}
+
+	return Flow{URL: u, State: state, Nonce: nonce, Verifier: verifier}, nil
+}
+
+func (s *svc) Complete(ctx context.Context, code string, f Flow) (domain.Admin, error) {
+	raw, err := s.provider.Exchange(ctx, code, f.Verifier)
+	if err != nil {
+		s.log.Warn("Complete exchange failed", "err", err)
+		return domain.Admin{}, domain.ErrUnauthorized
+	}
+
+	claims, err := s.provider.VerifyIDToken(ctx, raw, f.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) {
+			s.log.Warn("Complete id token rejected", "err", err)
+			return domain.Admin{}, domain.ErrUnauthorized
+		}
+		s.log.Error("Complete id token verification failed", "err", err)
+		return domain.Admin{}, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	if email == "" || !claims.EmailVerified {
+		s.log.Info("Complete failed", "subject", claims.Subject, "reason", "email_not_verified")
+		return domain.Admin{}, domain.ErrUnauthorized
+	}
+	username, ok := s.allowed[email]
+	if !ok {
+		s.log.Info("Complete failed", "email", email, "reason", "email_not_allowed")
+		return domain.Admin{}, domain.ErrUnauthorized
+	}
+
+	a, err := s.admins.GetByUsername(ctx, username)
+	if errors.Is(err, domain.ErrNotFound) {
+		s.log.Warn("Complete failed", "email", email, "username", username, "reason", "admin_not_found")
+		return domain.Admin{}, domain.ErrUnauthorized
+	}
+	if err != nil {
+		s.log.Error("Complete admins.GetByUsername failed", "username", username, "err", err)
+		return domain.Admin{}, err
+	}
+
+	s.log.Info("Complete success", "email", email, "username", a.Username, "admin_id", a.ID.String())
+	return a, nil
+}
+
+// ParseAllowedEmails разбирает OIDC_ALLOWED_EMAILS: "anna@studio.ru:admin,helper@studio.ru".
+func ParseAllowedEmails(entries []string) (map[string]string, error) {
+	out := make(map[string]string, len(entries))
+	for _, e := range entries {
+		email, username, hasUsername := strings.Cut(strings.TrimSpace(e), ":")
+		email = strings.ToLower(strings.TrimSpace(email))
+		local, _, ok := strings.Cut(email, "@")
+		if !ok || local == "" {
+			return nil, fmt.Errorf("oidc: allowed email %q: not an email", e)
+		}
+		if !hasUsername {
+			username = local
+		}
+		username = admin.NormalizeUsername(username)
+		if username == "" {
+			return nil, fmt.Errorf("oidc: allowed email %q: empty username", e)
+		}
+		out[email] = username
+	}
+	return out, nil
+}
@@ -0,0 +1,89 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret — ключ из приложения B RFC 6238 ("12345678901234567890") в base32.
+var rfcSecret = b32.EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeRFC6238Vectors(t *testing.T) {
+	// В RFC коды 8-значные; 6-значный код — их последние шесть цифр.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestCodeBadSecret(t *testing.T) {
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Fatal("Code with malformed secret: want error")
+	}
+}
+
+func TestVerifyWindow(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	cur := Step(now)
+
+	codeAt := func(step int64) string {
+		c, err := Code(rfcSecret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantOK   bool
+		wantStep int64
+	}{
+		{"current step", codeAt(cur), true, cur},
+		{"previous step", codeAt(cur - 1), true, cur - 1},
+		{"next step", codeAt(cur + 1), true, cur + 1},
+		{"two steps behind", codeAt(cur - 2), false, 0},
+		{"two steps ahead", codeAt(cur + 2), false, 0},
+		{"spaces ignored", codeAt(cur)[:3] + " " + codeAt(cur)[3:], true, cur},
+		{"too short", codeAt(cur)[:5], false, 0},
+		{"empty", "", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Verify(rfcSecret, tt.code, now, 1)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("Verify = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestVerifyNoSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	prev, err := Code(rfcSecret, Step(now)-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Verify(rfcSecret, prev, now, 0); ok {
+		t.Fatal("Verify with skew 0 accepted the previous step")
+	}
+}
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    scope         text        NOT NULL,
+    key           text        NOT NULL,
+
+    request_hash  text        NOT NULL,
+
+    status_code   integer     NULL,
+    response_body bytea       NULL,
+
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    completed_at  timestamptz NULL,
+    expires_at    timestamptz NOT NULL,
+
+    CONSTRAINT idempotency_keys_pk PRIMARY KEY (scope, key),
+    CONSTRAINT idempotency_keys_completion_consistency CHECK (
+        (completed_at IS NULL AND status_code IS NULL)
+            OR
+        (completed_at IS NOT NULL AND status_code IS NOT NULL)
+        )
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx
+    ON idempotency_keys (expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idempotency_keys_expires_at_idx;
+DROP TABLE IF EXISTS idempotency_keys;
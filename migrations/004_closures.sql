@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS closures
+(
+    id         uuid PRIMARY KEY     DEFAULT gen_random_uuid(),
+
+    start_date date        NOT NULL,
+    end_date   date        NOT NULL,
+    reason     text        NOT NULL,
+
+    created_at timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT closures_dates_valid CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS closures_dates_idx
+    ON closures USING gist (daterange(start_date, end_date, '[]'));
+
+-- +goose Down
+DROP INDEX IF EXISTS closures_dates_idx;
+DROP TABLE IF EXISTS closures;
@@ -0,0 +1,31 @@
+-- +goose Up
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS kind text NOT NULL DEFAULT 'client';
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_kind_valid CHECK (kind IN ('client', 'block'));
+
+ALTER TABLE bookings
+    ALTER COLUMN client_name DROP NOT NULL,
+    ALTER COLUMN client_phone DROP NOT NULL;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_client_required CHECK (
+        kind <> 'client' OR (client_name IS NOT NULL AND client_phone IS NOT NULL)
+        );
+
+-- +goose Down
+DELETE FROM bookings WHERE kind <> 'client';
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_client_required;
+
+ALTER TABLE bookings
+    ALTER COLUMN client_name SET NOT NULL,
+    ALTER COLUMN client_phone SET NOT NULL;
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_kind_valid;
+
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,47 @@
+-- +goose Up
+-- Интервал, занятый записью вместе с буферами до/после. Пишется приложением,
+-- т.к. сдвиг timestamptz на interval не IMMUTABLE и не годится для generated column.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS occupied_start_at timestamptz NULL,
+    ADD COLUMN IF NOT EXISTS occupied_end_at   timestamptz NULL;
+
+UPDATE bookings
+SET occupied_start_at = start_at,
+    occupied_end_at   = end_at
+WHERE occupied_start_at IS NULL
+   OR occupied_end_at IS NULL;
+
+ALTER TABLE bookings
+    ALTER COLUMN occupied_start_at SET NOT NULL,
+    ALTER COLUMN occupied_end_at SET NOT NULL;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_occupied_valid CHECK (occupied_start_at <= start_at AND occupied_end_at >= end_at);
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_no_overlap_active;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap_active
+        EXCLUDE USING gist (
+        tstzrange(occupied_start_at, occupied_end_at, '[)') WITH &&
+        )
+        WHERE (status = 'active');
+
+-- +goose Down
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_no_overlap_active;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap_active
+        EXCLUDE USING gist (
+        tstzrange(start_at, end_at, '[)') WITH &&
+        )
+        WHERE (status = 'active');
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_occupied_valid;
+
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS occupied_end_at,
+    DROP COLUMN IF EXISTS occupied_start_at;
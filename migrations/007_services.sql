@@ -0,0 +1,36 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS services
+(
+    id               uuid PRIMARY KEY     DEFAULT gen_random_uuid(),
+
+    name             text        NOT NULL,
+    description      text        NULL,
+    duration_minutes integer     NOT NULL,
+    price_rub        integer     NOT NULL,
+    is_active        boolean     NOT NULL DEFAULT true,
+
+    created_at       timestamptz NOT NULL DEFAULT now(),
+    updated_at       timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT services_duration_valid CHECK (duration_minutes > 0),
+    CONSTRAINT services_price_valid CHECK (price_rub >= 0)
+);
+
+-- Название и цена копируются в запись, чтобы изменение каталога не меняло историю.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS service_id   uuid    NULL REFERENCES services (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS service_name text    NULL,
+    ADD COLUMN IF NOT EXISTS price_rub    integer NULL;
+
+CREATE INDEX IF NOT EXISTS bookings_service_id_idx
+    ON bookings (service_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS bookings_service_id_idx;
+
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS price_rub,
+    DROP COLUMN IF EXISTS service_name,
+    DROP COLUMN IF EXISTS service_id;
+
+DROP TABLE IF EXISTS services;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Хранится только SHA-256 от значения cookie.
+CREATE TABLE IF NOT EXISTS sessions
+(
+    id           uuid PRIMARY KEY     DEFAULT gen_random_uuid(),
+    token_hash   text        NOT NULL,
+
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    last_seen_at timestamptz NOT NULL DEFAULT now(),
+    expires_at   timestamptz NOT NULL,
+
+    ip           text        NOT NULL DEFAULT '',
+    user_agent   text        NOT NULL DEFAULT '',
+
+    CONSTRAINT sessions_token_hash_uniq UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS sessions_expires_at_idx
+    ON sessions (expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS sessions_expires_at_idx;
+DROP TABLE IF EXISTS sessions;
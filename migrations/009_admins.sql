@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS admins
+(
+    id            uuid PRIMARY KEY     DEFAULT gen_random_uuid(),
+
+    username      text        NOT NULL,
+    password_hash text        NOT NULL,
+
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    updated_at    timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT admins_username_uniq UNIQUE (username)
+);
+
+-- Сессии без владельца больше не действительны.
+DELETE FROM sessions;
+
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS admin_id uuid NOT NULL REFERENCES admins (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS sessions_admin_id_idx
+    ON sessions (admin_id);
+
+-- Логин администратора на момент отмены.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS cancelled_by text NULL;
+
+-- +goose Down
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS cancelled_by;
+
+DROP INDEX IF EXISTS sessions_admin_id_idx;
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS admin_id;
+
+DROP TABLE IF EXISTS admins;
@@ -0,0 +1,40 @@
+-- +goose Up
+-- Счётчики неудачных входов: scope = 'ip' | 'username'.
+CREATE TABLE IF NOT EXISTS login_attempts
+(
+    scope           text        NOT NULL,
+    key             text        NOT NULL,
+
+    failures        integer     NOT NULL DEFAULT 0,
+    last_failure_at timestamptz NOT NULL,
+    locked_until    timestamptz NULL,
+
+    CONSTRAINT login_attempts_pk PRIMARY KEY (scope, key),
+    CONSTRAINT login_attempts_scope_valid CHECK (scope IN ('ip', 'username'))
+);
+
+CREATE INDEX IF NOT EXISTS login_attempts_last_failure_at_idx
+    ON login_attempts (last_failure_at);
+
+-- Журнал блокировок для админки.
+CREATE TABLE IF NOT EXISTS login_lockouts
+(
+    id           uuid PRIMARY KEY     DEFAULT gen_random_uuid(),
+
+    scope        text        NOT NULL,
+    key          text        NOT NULL,
+    failures     integer     NOT NULL,
+    locked_until timestamptz NOT NULL,
+
+    created_at   timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS login_lockouts_created_at_idx
+    ON login_lockouts (created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS login_lockouts_created_at_idx;
+DROP TABLE IF EXISTS login_lockouts;
+
+DROP INDEX IF EXISTS login_attempts_last_failure_at_idx;
+DROP TABLE IF EXISTS login_attempts;
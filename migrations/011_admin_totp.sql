@@ -0,0 +1,34 @@
+-- +goose Up
+-- totp_secret без totp_enabled_at — подключение начато, но первый код ещё не подтверждён.
+ALTER TABLE admins
+    ADD COLUMN IF NOT EXISTS totp_secret     text        NULL,
+    ADD COLUMN IF NOT EXISTS totp_enabled_at timestamptz NULL,
+    ADD COLUMN IF NOT EXISTS totp_last_step  bigint      NULL;
+
+CREATE TABLE IF NOT EXISTS admin_recovery_codes
+(
+    id         uuid PRIMARY KEY     DEFAULT gen_random_uuid(),
+    admin_id   uuid        NOT NULL REFERENCES admins (id) ON DELETE CASCADE,
+
+    code_hash  text        NOT NULL,
+    used_at    timestamptz NULL,
+
+    created_at timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT admin_recovery_codes_uniq UNIQUE (admin_id, code_hash)
+);
+
+-- Сессия после пароля, но до второго фактора.
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS mfa_pending boolean NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS mfa_pending;
+
+DROP TABLE IF EXISTS admin_recovery_codes;
+
+ALTER TABLE admins
+    DROP COLUMN IF EXISTS totp_last_step,
+    DROP COLUMN IF EXISTS totp_enabled_at,
+    DROP COLUMN IF EXISTS totp_secret;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Существующие учётки были полноправными — остаются владельцами.
+ALTER TABLE admins
+    ADD COLUMN IF NOT EXISTS role text NOT NULL DEFAULT 'owner',
+    ADD CONSTRAINT admins_role_chk CHECK (role IN ('owner', 'manager', 'viewer'));
+
+-- +goose Down
+ALTER TABLE admins
+    DROP CONSTRAINT IF EXISTS admins_role_chk,
+    DROP COLUMN IF EXISTS role;
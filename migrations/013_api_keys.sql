@@ -0,0 +1,25 @@
+-- +goose Up
+-- API-ключи для скриптов и интеграций; хранится только sha256 ключа.
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id           uuid PRIMARY KEY     DEFAULT gen_random_uuid(),
+    name         text        NOT NULL,
+    prefix       text        NOT NULL,
+    key_hash     text        NOT NULL,
+    scopes       text[]      NOT NULL,
+
+    created_by   uuid        NOT NULL REFERENCES admins (id) ON DELETE CASCADE,
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    expires_at   timestamptz NULL,
+    last_used_at timestamptz NULL,
+    revoked_at   timestamptz NULL,
+
+    CONSTRAINT api_keys_key_hash_uniq UNIQUE (key_hash),
+    CONSTRAINT api_keys_scopes_valid CHECK (
+        cardinality(scopes) > 0
+            AND scopes <@ ARRAY ['schedule:read', 'schedule:write', 'clients:pii', 'admins:manage']::text[]
+        )
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Ссылка для клиента на просмотр и отмену своей записи; хранится только sha256 токена.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS manage_token_hash text NULL,
+    ADD COLUMN IF NOT EXISTS cancel_source     text NULL;
+
+-- До этой миграции отменять мог только администратор.
+UPDATE bookings
+SET cancel_source = 'admin'
+WHERE status = 'cancelled'
+  AND cancel_source IS NULL;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_manage_token_hash_uniq UNIQUE (manage_token_hash);
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_cancel_source_valid CHECK (cancel_source IN ('admin', 'client'));
+
+-- +goose Down
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_cancel_source_valid;
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_manage_token_hash_uniq;
+
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS cancel_source,
+    DROP COLUMN IF EXISTS manage_token_hash;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- История переносов записи: прежнее и новое время, кто перенёс.
+CREATE TABLE IF NOT EXISTS booking_reschedules
+(
+    id             uuid PRIMARY KEY     DEFAULT gen_random_uuid(),
+    booking_id     uuid        NOT NULL REFERENCES bookings (id) ON DELETE CASCADE,
+
+    old_start_at   timestamptz NOT NULL,
+    old_end_at     timestamptz NOT NULL,
+    new_start_at   timestamptz NOT NULL,
+    new_end_at     timestamptz NOT NULL,
+
+    source         text        NOT NULL,
+    rescheduled_by text        NULL,
+
+    created_at     timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT booking_reschedules_source_valid CHECK (source IN ('admin', 'client'))
+);
+
+CREATE INDEX IF NOT EXISTS booking_reschedules_booking_id_idx
+    ON booking_reschedules (booking_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS booking_reschedules_booking_id_idx;
+DROP TABLE IF EXISTS booking_reschedules;
@@ -0,0 +1,90 @@
+-- +goose Up
+-- Жизненный цикл записи: pending → confirmed → completed | no_show, отмена из любого незавершённого состояния.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS confirmed_at timestamptz NULL,
+    ADD COLUMN IF NOT EXISTS completed_at timestamptz NULL,
+    ADD COLUMN IF NOT EXISTS no_show_at   timestamptz NULL;
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_no_overlap_active,
+    DROP CONSTRAINT IF EXISTS bookings_cancel_consistency,
+    DROP CONSTRAINT IF EXISTS bookings_status_valid;
+
+-- До этой миграции запись считалась подтверждённой с момента создания.
+UPDATE bookings
+SET confirmed_at = created_at
+WHERE confirmed_at IS NULL;
+
+UPDATE bookings
+SET status = 'confirmed'
+WHERE status = 'active';
+
+ALTER TABLE bookings
+    ALTER COLUMN status SET DEFAULT 'confirmed';
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_status_valid CHECK (status IN ('pending', 'confirmed', 'completed', 'no_show', 'cancelled'));
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_cancel_consistency CHECK ((status = 'cancelled') = (cancelled_at IS NOT NULL));
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_confirm_consistency CHECK (
+        (status = 'pending' AND confirmed_at IS NULL)
+            OR
+        (status IN ('confirmed', 'completed', 'no_show') AND confirmed_at IS NOT NULL)
+            OR
+        status = 'cancelled'
+        );
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_complete_consistency CHECK ((status = 'completed') = (completed_at IS NOT NULL));
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_show_consistency CHECK ((status = 'no_show') = (no_show_at IS NOT NULL));
+
+-- Время занимает любая неотменённая запись, в том числе ожидающая подтверждения.
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap_active
+        EXCLUDE USING gist (
+        tstzrange(occupied_start_at, occupied_end_at, '[)') WITH &&
+        )
+        WHERE (status <> 'cancelled');
+
+-- +goose Down
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_no_overlap_active,
+    DROP CONSTRAINT IF EXISTS bookings_no_show_consistency,
+    DROP CONSTRAINT IF EXISTS bookings_complete_consistency,
+    DROP CONSTRAINT IF EXISTS bookings_confirm_consistency,
+    DROP CONSTRAINT IF EXISTS bookings_cancel_consistency,
+    DROP CONSTRAINT IF EXISTS bookings_status_valid;
+
+UPDATE bookings
+SET status = 'active'
+WHERE status <> 'cancelled';
+
+ALTER TABLE bookings
+    ALTER COLUMN status SET DEFAULT 'active';
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_status_valid CHECK (status IN ('active', 'cancelled'));
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_cancel_consistency CHECK (
+        (status = 'active' AND cancelled_at IS NULL)
+            OR
+        (status = 'cancelled' AND cancelled_at IS NOT NULL)
+        );
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap_active
+        EXCLUDE USING gist (
+        tstzrange(occupied_start_at, occupied_end_at, '[)') WITH &&
+        )
+        WHERE (status = 'active');
+
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS no_show_at,
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS confirmed_at;
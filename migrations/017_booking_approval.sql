@@ -0,0 +1,44 @@
+-- +goose Up
+-- Режим подтверждения: заявка в состоянии pending держит время до pending_expires_at,
+-- после чего фоновая задача отменяет её (cancel_source = 'system').
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS pending_expires_at timestamptz NULL;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_pending_expiry_valid CHECK (status <> 'pending' OR pending_expires_at IS NOT NULL);
+
+CREATE INDEX IF NOT EXISTS bookings_pending_expires_at_idx
+    ON bookings (pending_expires_at)
+    WHERE (status = 'pending');
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_cancel_source_valid;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_cancel_source_valid CHECK (cancel_source IN ('admin', 'client', 'system'));
+
+-- NULL — как в глобальной настройке BOOKING_APPROVAL_REQUIRED.
+ALTER TABLE services
+    ADD COLUMN IF NOT EXISTS requires_approval boolean NULL;
+
+-- +goose Down
+ALTER TABLE services
+    DROP COLUMN IF EXISTS requires_approval;
+
+UPDATE bookings
+SET cancel_source = 'admin'
+WHERE cancel_source = 'system';
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_cancel_source_valid;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_cancel_source_valid CHECK (cancel_source IN ('admin', 'client'));
+
+DROP INDEX IF EXISTS bookings_pending_expires_at_idx;
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_pending_expiry_valid;
+
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS pending_expires_at;
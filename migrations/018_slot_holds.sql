@@ -0,0 +1,42 @@
+-- +goose Up
+-- Удержание слота, пока клиент заполняет форму записи: строка kind = 'hold' участвует
+-- в bookings_no_overlap_active наравне с записями и блокировками до hold_expires_at.
+-- Истёкшие удержания удаляет фоновая задача; до этого их игнорируют выборки и вставка новых записей.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS hold_token_hash text NULL,
+    ADD COLUMN IF NOT EXISTS hold_expires_at timestamptz NULL;
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_kind_valid;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_kind_valid CHECK (kind IN ('client', 'block', 'hold'));
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_hold_valid CHECK (
+        (kind = 'hold') = (hold_token_hash IS NOT NULL AND hold_expires_at IS NOT NULL)
+        );
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_hold_token_hash_uniq UNIQUE (hold_token_hash);
+
+CREATE INDEX IF NOT EXISTS bookings_hold_expires_at_idx
+    ON bookings (hold_expires_at)
+    WHERE (kind = 'hold');
+
+-- +goose Down
+DELETE FROM bookings WHERE kind = 'hold';
+
+DROP INDEX IF EXISTS bookings_hold_expires_at_idx;
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_hold_token_hash_uniq,
+    DROP CONSTRAINT IF EXISTS bookings_hold_valid,
+    DROP CONSTRAINT IF EXISTS bookings_kind_valid;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_kind_valid CHECK (kind IN ('client', 'block'));
+
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS hold_expires_at,
+    DROP COLUMN IF EXISTS hold_token_hash;
@@ -0,0 +1,58 @@
+-- +goose Up
+-- Справочник клиентов: один клиент на телефон (bookings_phone_valid уже приводит его к виду +7XXXXXXXXXX).
+-- Слитый дубликат остаётся с merged_into — новые записи с его телефоном попадают к основному клиенту.
+CREATE TABLE IF NOT EXISTS clients
+(
+    id          uuid PRIMARY KEY     DEFAULT gen_random_uuid(),
+    phone       text        NOT NULL,
+    name        text        NOT NULL,
+    merged_into uuid        NULL REFERENCES clients (id),
+
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    updated_at  timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT clients_phone_uniq UNIQUE (phone),
+    CONSTRAINT clients_phone_valid CHECK (phone ~ '^\+7\d{10}$'),
+    CONSTRAINT clients_merged_into_other CHECK (merged_into <> id)
+);
+
+CREATE INDEX IF NOT EXISTS clients_merged_into_idx
+    ON clients (merged_into)
+    WHERE (merged_into IS NOT NULL);
+
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS client_id uuid NULL REFERENCES clients (id);
+
+-- Имя клиента — из его первой записи.
+INSERT INTO clients (phone, name, created_at)
+SELECT DISTINCT ON (client_phone) client_phone, client_name, created_at
+FROM bookings
+WHERE kind = 'client'
+ORDER BY client_phone, created_at ASC
+ON CONFLICT (phone) DO NOTHING;
+
+UPDATE bookings b
+SET client_id = c.id
+FROM clients c
+WHERE b.kind = 'client'
+  AND b.client_id IS NULL
+  AND c.phone = b.client_phone;
+
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_client_linked CHECK (kind <> 'client' OR client_id IS NOT NULL);
+
+CREATE INDEX IF NOT EXISTS bookings_client_id_idx
+    ON bookings (client_id)
+    WHERE (client_id IS NOT NULL);
+
+-- +goose Down
+DROP INDEX IF EXISTS bookings_client_id_idx;
+
+ALTER TABLE bookings
+    DROP CONSTRAINT IF EXISTS bookings_client_linked;
+
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS client_id;
+
+DROP INDEX IF EXISTS clients_merged_into_idx;
+DROP TABLE IF EXISTS clients;
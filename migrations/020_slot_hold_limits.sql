@@ -0,0 +1,26 @@
+-- +goose Up
+-- Ограничения публичного удержания слотов: hold_requester — кто удерживает (IP клиента),
+-- slot_hold_requests — журнал созданных удержаний для лимита частоты; старые строки удаляет фоновая задача.
+ALTER TABLE bookings
+    ADD COLUMN IF NOT EXISTS hold_requester text NULL;
+
+CREATE INDEX IF NOT EXISTS bookings_hold_requester_idx
+    ON bookings (hold_requester, hold_expires_at)
+    WHERE (kind = 'hold');
+
+CREATE TABLE IF NOT EXISTS slot_hold_requests
+(
+    requester  text        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS slot_hold_requests_requester_created_at_idx
+    ON slot_hold_requests (requester, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS slot_hold_requests;
+
+DROP INDEX IF EXISTS bookings_hold_requester_idx;
+
+ALTER TABLE bookings
+    DROP COLUMN IF EXISTS hold_requester;